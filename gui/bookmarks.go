@@ -0,0 +1,108 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type bookmark struct {
+	Name  string    `json:"name"`
+	Notes string    `json:"notes,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+// Returns bookmarks which name or notes contain query, ignoring case,
+// all of them for empty query
+func filterBookmarks(bookmarks []bookmark, query string) []bookmark {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	filtered := make([]bookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		if query == "" ||
+			strings.Contains(strings.ToLower(b.Name), query) ||
+			strings.Contains(strings.ToLower(b.Notes), query) {
+			filtered = append(filtered, b)
+		}
+	}
+
+	return filtered
+}
+
+// Returns bookmarks with new one added at the end,
+// names have to be unique, so bookmark can be found by it
+func addBookmark(bookmarks []bookmark, b bookmark) ([]bookmark, error) {
+	b.Name = strings.TrimSpace(b.Name)
+	if b.Name == "" {
+		return nil, fmt.Errorf("bookmark name cannot be empty")
+	}
+
+	for _, existing := range bookmarks {
+		if existing.Name == b.Name {
+			return nil, fmt.Errorf("bookmark %s already exists", b.Name)
+		}
+	}
+
+	return append(append([]bookmark{}, bookmarks...), b), nil
+}
+
+// Returns bookmarks without the one of given name
+func removeBookmark(bookmarks []bookmark, name string) []bookmark {
+	remaining := make([]bookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		if b.Name != name {
+			remaining = append(remaining, b)
+		}
+	}
+
+	return remaining
+}
+
+// Drops bookmarks without name and later ones with repeated name,
+// e.g. from hand edited file
+func normalizeBookmarks(bookmarks []bookmark) []bookmark {
+	normalized := make([]bookmark, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		if added, err := addBookmark(normalized, b); err == nil {
+			normalized = added
+		}
+	}
+
+	return normalized
+}
+
+// Returns bookmarks with imported ones added at the end, one with the same
+// name and timestamp as existing one is the same bookmark and is skipped,
+// other one with taken name gets a number, so names stay unique
+func mergeBookmarks(bookmarks []bookmark, imported []bookmark) []bookmark {
+	merged := append([]bookmark{}, bookmarks...)
+
+	for _, b := range normalizeBookmarks(imported) {
+		if containsBookmark(merged, b) {
+			continue
+		}
+
+		name := b.Name
+		for i := 2; ; i++ {
+			added, err := addBookmark(merged, b)
+			if err == nil {
+				merged = added
+				break
+			}
+
+			b.Name = fmt.Sprintf("%s (%d)", name, i)
+		}
+	}
+
+	return merged
+}
+
+func containsBookmark(bookmarks []bookmark, b bookmark) bool {
+	for _, existing := range bookmarks {
+		if existing.Name == b.Name && existing.Time.Equal(b.Time) {
+			return true
+		}
+	}
+
+	return false
+}
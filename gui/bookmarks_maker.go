@@ -0,0 +1,284 @@
+package gui
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"reflect"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	prefSync "github.com/sharki13/timestamp-converter/preferences"
+)
+
+const (
+	bookmarksKey      = "bookmarks"
+	bookmarksFileName = "timestamp-converter-bookmarks.json"
+	bookmarkLayout    = "2006-01-02 15:04:05 MST"
+
+	bookmarksPanelWidth = 250
+)
+
+// Registers bookmarks and visibility of their panel
+func (t *TimestampConverter) setupBookmarks() {
+	err := prefSync.Add[[]bookmark](t.preferences, prefSync.Preference[[]bookmark]{
+		Key:       bookmarksKey,
+		Value:     &t.bookmarks,
+		Fallback:  []bookmark{},
+		Codec:     prefSync.JSONCodec[[]bookmark]{},
+		Normalize: normalizeBookmarks,
+	})
+
+	if err != nil {
+		t.reportError(err)
+	}
+
+	err = t.preferences.AddBool(prefSync.BoolPreference{
+		Key:      "showBookmarks",
+		Value:    t.showBookmarks,
+		Fallback: false,
+	})
+
+	if err != nil {
+		t.reportError(err)
+	}
+}
+
+// Panel on the right side with bookmarks, search and buttons to manage them,
+// hidden until showBookmarks is set
+func (t *TimestampConverter) makeBookmarksPanel() fyne.CanvasObject {
+	search := widget.NewEntry()
+	search.SetPlaceHolder(SearchLabel)
+
+	shown := make([]bookmark, 0)
+
+	list := widget.NewList(
+		func() int {
+			return len(shown)
+		},
+		func() fyne.CanvasObject {
+			name := widget.NewLabel("")
+			name.TextStyle = fyne.TextStyle{Bold: true}
+			details := widget.NewLabel("")
+			details.Wrapping = fyne.TextWrapWord
+			return container.NewVBox(name, details)
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			b := shown[id]
+			labels := o.(*fyne.Container).Objects
+
+			labels[0].(*widget.Label).SetText(b.Name)
+
+			details := b.Time.Local().Format(bookmarkLayout)
+			if b.Notes != "" {
+				details += "\n" + b.Notes
+			}
+			labels[1].(*widget.Label).SetText(details)
+		},
+	)
+
+	selected := ""
+
+	refresh := func() {
+		bookmarks, err := t.bookmarks.Get()
+		if err != nil {
+			t.reportError(err)
+			return
+		}
+
+		filtered := filterBookmarks(bookmarks, search.Text)
+
+		// nothing to redraw, e.g. on the first call while the window is being built
+		if reflect.DeepEqual(filtered, shown) {
+			return
+		}
+
+		shown = filtered
+		selected = ""
+		list.UnselectAll()
+		list.Refresh()
+	}
+
+	search.OnChanged = func(string) { refresh() }
+	t.bookmarks.AddListener(binding.NewDataListener(refresh))
+
+	list.OnSelected = func(id widget.ListItemID) {
+		if id >= len(shown) {
+			return
+		}
+
+		selected = shown[id].Name
+		t.setTimestamp(shown[id].Time, historyBookmark)
+	}
+
+	add := widget.NewButtonWithIcon("", theme.ContentAddIcon(), t.showAddBookmarkDialog)
+
+	remove := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+		if selected != "" {
+			t.confirmDeleteBookmark(selected)
+		}
+	})
+
+	export := widget.NewButtonWithIcon("", theme.DocumentSaveIcon(), func() {
+		t.showExportDialog(bookmarksFileName, func() ([]byte, error) {
+			return t.preferences.ExportKeys([]string{bookmarksKey})
+		})
+	})
+
+	importBtn := widget.NewButtonWithIcon("", theme.FolderOpenIcon(), t.showImportBookmarksDialog)
+
+	title := widget.NewLabel(BookmarksLabel)
+	title.TextStyle = fyne.TextStyle{Bold: true}
+
+	top := container.NewVBox(
+		container.NewBorder(nil, nil, nil, container.NewHBox(add, remove, export, importBtn), title),
+		search,
+	)
+
+	// list alone would be as narrow as its empty labels
+	width := canvas.NewRectangle(color.Transparent)
+	width.SetMinSize(fyne.NewSize(bookmarksPanelWidth, 0))
+
+	panel := container.NewMax(width, container.NewBorder(top, nil, nil, nil, list))
+
+	// shown or hidden right away, the listener changes it only when
+	// visibility changes, not while the window is being built
+	show, _ := t.showBookmarks.Get()
+	panel.Hidden = !show
+
+	t.showBookmarks.AddListener(binding.NewDataListener(func() {
+		show, err := t.showBookmarks.Get()
+		if err != nil {
+			t.reportError(err)
+			return
+		}
+
+		if show == panel.Visible() {
+			return
+		}
+
+		if show {
+			panel.Show()
+		} else {
+			panel.Hide()
+		}
+	}))
+
+	return panel
+}
+
+// Asks for name and notes, then bookmarks the current timestamp
+func (t *TimestampConverter) showAddBookmarkDialog() {
+	timestamp, err := t.timestamp.Get()
+	if err != nil {
+		t.reportError(err)
+		return
+	}
+
+	name := widget.NewEntry()
+	notes := widget.NewMultiLineEntry()
+
+	dialog.ShowForm(AddBookmarkLabel, CreateLabel, CancelLabel, []*widget.FormItem{
+		widget.NewFormItem(BookmarkTimeLabel, widget.NewLabel(timestamp.Local().Format(bookmarkLayout))),
+		widget.NewFormItem(BookmarkNameLabel, name),
+		widget.NewFormItem(NotesLabel, notes),
+	}, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		bookmarks, err := t.bookmarks.Get()
+		if err != nil {
+			t.reportError(err)
+			return
+		}
+
+		bookmarks, err = addBookmark(bookmarks, bookmark{Name: name.Text, Notes: notes.Text, Time: timestamp})
+		if err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+
+		t.bookmarks.Set(bookmarks)
+		t.showBookmarks.Set(true)
+	}, t.window)
+}
+
+// Adds bookmarks from exported file to the current ones, settings and other
+// values in the file are ignored
+func (t *TimestampConverter) showImportBookmarksDialog() {
+	t.showImportDialog(func(data []byte) error {
+		added, err := t.importBookmarks(data)
+		if err != nil {
+			return err
+		}
+
+		dialog.ShowInformation(BookmarksLabel, fmt.Sprintf("%d bookmarks imported.", added), t.window)
+		return nil
+	})
+}
+
+// Merges bookmarks of the file with the current ones, returns how many were added
+func (t *TimestampConverter) importBookmarks(data []byte) (int, error) {
+	raw, ok, err := t.preferences.ImportKey(data, bookmarksKey)
+	if err != nil {
+		return 0, err
+	}
+
+	if !ok {
+		return 0, fmt.Errorf("file has no bookmarks")
+	}
+
+	imported := []bookmark{}
+	if err := json.Unmarshal(raw, &imported); err != nil {
+		return 0, fmt.Errorf("cannot read bookmarks: %w", err)
+	}
+
+	bookmarks, err := t.bookmarks.Get()
+	if err != nil {
+		return 0, err
+	}
+
+	merged := mergeBookmarks(bookmarks, imported)
+	if err := t.bookmarks.Set(merged); err != nil {
+		return 0, err
+	}
+
+	t.showBookmarks.Set(true)
+
+	return len(merged) - len(bookmarks), nil
+}
+
+func (t *TimestampConverter) confirmDeleteBookmark(name string) {
+	dialog.ShowConfirm(DeleteBookmarkLabel, fmt.Sprintf("Delete bookmark %s?", name), func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		bookmarks, err := t.bookmarks.Get()
+		if err != nil {
+			t.reportError(err)
+			return
+		}
+
+		t.bookmarks.Set(removeBookmark(bookmarks, name))
+	}, t.window)
+}
+
+// Toolbar button which shows and hides bookmarks panel
+func (t *TimestampConverter) makeBookmarksButton() *widget.Button {
+	return widget.NewButtonWithIcon(BookmarksLabel, theme.ListIcon(), func() {
+		show, err := t.showBookmarks.Get()
+		if err != nil {
+			t.reportError(err)
+			return
+		}
+
+		t.showBookmarks.Set(!show)
+	})
+}
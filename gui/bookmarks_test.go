@@ -0,0 +1,106 @@
+package gui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func bookmarkNames(bookmarks []bookmark) []string {
+	names := make([]string, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		names = append(names, b.Name)
+	}
+
+	return names
+}
+
+func TestFilterBookmarks(t *testing.T) {
+	bookmarks := []bookmark{
+		{Name: "Release cutoff", Notes: "v2.0 branch", Time: at(1)},
+		{Name: "Incident start", Notes: "first alert from EU", Time: at(2)},
+		{Name: "Standup", Time: at(3)},
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"Release cutoff", "Incident start", "Standup"}},
+		{"  ", []string{"Release cutoff", "Incident start", "Standup"}},
+		{"release", []string{"Release cutoff"}},
+		{"ST", []string{"Incident start", "Standup"}},
+		{"alert", []string{"Incident start"}},
+		{"nothing", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := bookmarkNames(filterBookmarks(bookmarks, tt.query)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterBookmarks(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddAndRemoveBookmark(t *testing.T) {
+	bookmarks, err := addBookmark(nil, bookmark{Name: " Release ", Time: at(1)})
+	if err != nil {
+		t.Fatalf("addBookmark() error = %v", err)
+	}
+
+	if bookmarks[0].Name != "Release" {
+		t.Errorf("name = %q, want it trimmed", bookmarks[0].Name)
+	}
+
+	if _, err := addBookmark(bookmarks, bookmark{Name: "Release", Time: at(2)}); err == nil {
+		t.Errorf("addBookmark() with repeated name should fail")
+	}
+
+	if _, err := addBookmark(bookmarks, bookmark{Name: "  "}); err == nil {
+		t.Errorf("addBookmark() without name should fail")
+	}
+
+	bookmarks, _ = addBookmark(bookmarks, bookmark{Name: "Incident", Time: at(2)})
+	if got := bookmarkNames(removeBookmark(bookmarks, "Release")); !reflect.DeepEqual(got, []string{"Incident"}) {
+		t.Errorf("removeBookmark() = %v, want [Incident]", got)
+	}
+}
+
+func TestNormalizeBookmarks(t *testing.T) {
+	got := bookmarkNames(normalizeBookmarks([]bookmark{
+		{Name: "a"},
+		{Name: ""},
+		{Name: "b"},
+		{Name: "a"},
+	}))
+
+	if !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("normalizeBookmarks() = %v, want [a b]", got)
+	}
+}
+
+func TestMergeBookmarks(t *testing.T) {
+	bookmarks := []bookmark{
+		{Name: "Release", Time: at(1)},
+		{Name: "Incident", Time: at(2)},
+	}
+
+	got := mergeBookmarks(bookmarks, []bookmark{
+		{Name: "Release", Time: at(1)},
+		{Name: "Incident", Time: at(3)},
+		{Name: "Standup", Time: at(4)},
+		{Name: "Standup", Time: at(5)},
+	})
+
+	want := []string{"Release", "Incident", "Incident (2)", "Standup"}
+	if names := bookmarkNames(got); !reflect.DeepEqual(names, want) {
+		t.Errorf("mergeBookmarks() = %v, want %v", names, want)
+	}
+
+	if !got[2].Time.Equal(at(3)) {
+		t.Errorf("renamed bookmark should keep its timestamp, got %v", got[2].Time)
+	}
+
+	if len(bookmarks) != 2 {
+		t.Errorf("mergeBookmarks() should not change current bookmarks")
+	}
+}
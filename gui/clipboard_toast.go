@@ -0,0 +1,108 @@
+package gui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// How long timestamp replaced by clipboard watcher can be restored
+const clipboardUndoWindow = 5 * time.Second
+
+// Bar at the bottom of the window telling that clipboard watcher
+// changed the timestamp, with a button to revert it
+type clipboardToast struct {
+	container *fyne.Container
+	label     *widget.Label
+
+	lock     sync.Mutex
+	timer    *time.Timer
+	previous time.Time
+}
+
+func (t *TimestampConverter) makeClipboardToast() fyne.CanvasObject {
+	toast := &clipboardToast{
+		label: widget.NewLabel(""),
+	}
+
+	undo := widget.NewButtonWithIcon(UndoLabel, theme.ContentUndoIcon(), func() {
+		if previous, ok := toast.take(); ok {
+			t.setTimestamp(previous, historyRestored)
+		}
+	})
+
+	toast.container = container.NewBorder(nil, nil, nil, undo, toast.label)
+	toast.container.Hide()
+	t.toast = toast
+
+	return toast.container
+}
+
+// Shows toast with detected timestamp, previous one can be restored until it hides
+func (c *clipboardToast) show(text string, previous time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.previous = previous
+
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+
+	c.timer = time.AfterFunc(clipboardUndoWindow, func() {
+		c.take()
+	})
+
+	c.label.SetText(text)
+	c.container.Show()
+}
+
+// Hides toast and returns timestamp to restore, false if undo window is over
+func (c *clipboardToast) take() (time.Time, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.container.Visible() {
+		return time.Time{}, false
+	}
+
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+
+	c.container.Hide()
+
+	return c.previous, true
+}
+
+// Tells user that clipboard watcher replaced the timestamp,
+// in the window and optionally with desktop notification
+func (t *TimestampConverter) notifyClipboardTimestamp(detected time.Time, previous time.Time) {
+	format, err := t.format.Get()
+	if err != nil {
+		t.reportError(err)
+		return
+	}
+
+	text := fmt.Sprintf("Timestamp from clipboard: %s", detected.Local().Format(format))
+
+	if t.toast != nil {
+		t.toast.show(text, previous)
+	}
+
+	notify, err := t.notifyClipboard.Get()
+	if err != nil {
+		t.reportError(err)
+		return
+	}
+
+	if notify {
+		t.app.SendNotification(fyne.NewNotification(TimestampConverterLabel, text))
+	}
+}
@@ -0,0 +1,98 @@
+package gui
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// How often clipboard is checked when watching is on
+const clipboardWatchInterval = time.Second
+
+// Polls clipboard and calls onChange once for every new content,
+// only while enabled, it is safe to enable it from any goroutine
+type clipboardWatcher struct {
+	clipboard fyne.Clipboard
+	interval  time.Duration
+	onChange  func(content string)
+	enabled   atomic.Bool
+
+	// hash of the last handled content, content itself is not kept
+	lock     sync.Mutex
+	lastHash uint64
+	handled  bool
+}
+
+func newClipboardWatcher(clipboard fyne.Clipboard, interval time.Duration, onChange func(content string)) *clipboardWatcher {
+	return &clipboardWatcher{
+		clipboard: clipboard,
+		interval:  interval,
+		onChange:  onChange,
+	}
+}
+
+// Turns watching on or off, content in the clipboard when it is turned on
+// is handled on the next check
+func (w *clipboardWatcher) SetEnabled(enabled bool) {
+	if w.enabled.Swap(enabled) == enabled {
+		return
+	}
+
+	slog.Debug("clipboard watching changed", "enabled", enabled)
+
+	w.lock.Lock()
+	w.handled = false
+	w.lock.Unlock()
+}
+
+func (w *clipboardWatcher) Enabled() bool {
+	return w.enabled.Load()
+}
+
+// Checks clipboard every interval until context is done
+func (w *clipboardWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// Calls onChange if clipboard content differs from the last handled one
+func (w *clipboardWatcher) check() {
+	if !w.Enabled() || w.clipboard == nil {
+		return
+	}
+
+	content := w.clipboard.Content()
+	if content == "" {
+		return
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(content))
+	sum := hash.Sum64()
+
+	w.lock.Lock()
+	if w.handled && w.lastHash == sum {
+		w.lock.Unlock()
+		return
+	}
+
+	w.lastHash = sum
+	w.handled = true
+	w.lock.Unlock()
+
+	w.onChange(content)
+}
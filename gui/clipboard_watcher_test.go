@@ -0,0 +1,213 @@
+package gui
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+)
+
+// Clipboard kept in memory, safe to use from watcher goroutine
+type fakeClipboard struct {
+	lock    sync.Mutex
+	content string
+}
+
+func (c *fakeClipboard) Content() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.content
+}
+
+func (c *fakeClipboard) SetContent(content string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.content = content
+}
+
+// Collects contents passed to onChange
+type changeRecorder struct {
+	lock     sync.Mutex
+	contents []string
+}
+
+func (r *changeRecorder) record(content string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.contents = append(r.contents, content)
+}
+
+func (r *changeRecorder) get() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]string{}, r.contents...)
+}
+
+func TestClipboardWatcher_Check(t *testing.T) {
+	tests := []struct {
+		name string
+		// steps run one after another, each followed by check
+		steps []func(c *fakeClipboard, w *clipboardWatcher)
+		want  []string
+	}{
+		{
+			name: "Disabled",
+			steps: []func(c *fakeClipboard, w *clipboardWatcher){
+				func(c *fakeClipboard, w *clipboardWatcher) { c.SetContent("a") },
+			},
+			want: []string{},
+		},
+		{
+			name: "EachChangeOnce",
+			steps: []func(c *fakeClipboard, w *clipboardWatcher){
+				func(c *fakeClipboard, w *clipboardWatcher) { w.SetEnabled(true); c.SetContent("a") },
+				func(c *fakeClipboard, w *clipboardWatcher) {},
+				func(c *fakeClipboard, w *clipboardWatcher) { c.SetContent("b") },
+				func(c *fakeClipboard, w *clipboardWatcher) {},
+				func(c *fakeClipboard, w *clipboardWatcher) { c.SetContent("a") },
+			},
+			want: []string{"a", "b", "a"},
+		},
+		{
+			name: "EmptyIgnored",
+			steps: []func(c *fakeClipboard, w *clipboardWatcher){
+				func(c *fakeClipboard, w *clipboardWatcher) { w.SetEnabled(true) },
+				func(c *fakeClipboard, w *clipboardWatcher) { c.SetContent("a") },
+			},
+			want: []string{"a"},
+		},
+		{
+			name: "ChangesWhileDisabledSkipped",
+			steps: []func(c *fakeClipboard, w *clipboardWatcher){
+				func(c *fakeClipboard, w *clipboardWatcher) { w.SetEnabled(true); c.SetContent("a") },
+				func(c *fakeClipboard, w *clipboardWatcher) { w.SetEnabled(false); c.SetContent("b") },
+				func(c *fakeClipboard, w *clipboardWatcher) { c.SetContent("c") },
+			},
+			want: []string{"a"},
+		},
+		{
+			name: "ReenabledHandlesCurrentContent",
+			steps: []func(c *fakeClipboard, w *clipboardWatcher){
+				func(c *fakeClipboard, w *clipboardWatcher) { w.SetEnabled(true); c.SetContent("a") },
+				func(c *fakeClipboard, w *clipboardWatcher) { w.SetEnabled(false) },
+				func(c *fakeClipboard, w *clipboardWatcher) { w.SetEnabled(true) },
+				func(c *fakeClipboard, w *clipboardWatcher) {},
+			},
+			want: []string{"a", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clipboard := &fakeClipboard{}
+			recorder := &changeRecorder{}
+			w := newClipboardWatcher(clipboard, time.Hour, recorder.record)
+
+			for _, step := range tt.steps {
+				step(clipboard, w)
+				w.check()
+			}
+
+			got := recorder.get()
+			if len(got) != len(tt.want) {
+				t.Fatalf("onChange called with %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("onChange called with %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestClipboardWatcher_Run(t *testing.T) {
+	clipboard := &fakeClipboard{}
+	recorder := &changeRecorder{}
+	w := newClipboardWatcher(clipboard, 5*time.Millisecond, recorder.record)
+	w.SetEnabled(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+
+	go func() {
+		w.Run(ctx)
+		close(stopped)
+	}()
+
+	clipboard.SetContent("2023-03-14T15:09:26Z")
+
+	deadline := time.Now().Add(time.Second)
+	for len(recorder.get()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// a few more intervals with the same content
+	time.Sleep(30 * time.Millisecond)
+
+	if got := recorder.get(); len(got) != 1 {
+		t.Errorf("onChange called %d times, want once", len(got))
+	}
+
+	cancel()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("Run did not stop after context was cancelled")
+	}
+
+	clipboard.SetContent("other")
+	time.Sleep(20 * time.Millisecond)
+
+	if got := recorder.get(); len(got) != 1 {
+		t.Errorf("onChange called after Run stopped: %v", got)
+	}
+}
+
+type lockedBuffer struct {
+	buffer bytes.Buffer
+	lock   sync.Mutex
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.buffer.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.buffer.String()
+}
+
+func TestConverter_ClipboardNotLogged(t *testing.T) {
+	previous := slog.Default()
+	defer slog.SetDefault(previous)
+
+	// listeners log from their own goroutine
+	logged := &lockedBuffer{}
+	slog.SetDefault(slog.New(slog.NewTextHandler(logged, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	converter := startConverter(t, test.NewApp())
+	converter.onClipboardChanged("correct horse battery staple")
+	converter.onClipboardChanged("2023-01-02T03:04:05+01:00")
+
+	for _, content := range []string{"correct horse", "2023-01-02T03:04:05+01:00"} {
+		if strings.Contains(logged.String(), content) {
+			t.Errorf("clipboard content %q should not be logged", content)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package gui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	debugLogWidth  = 800
+	debugLogHeight = 500
+)
+
+// Window with recent log lines, new lines show up while it is open
+func (t *TimestampConverter) showDebugLog() {
+	if t.debugLog == nil {
+		return
+	}
+
+	window := t.app.NewWindow(DebugLogLabel)
+
+	lines := widget.NewLabel(t.debugLog.String())
+	lines.TextStyle.Monospace = true
+	scroll := container.NewScroll(lines)
+
+	stopListening := t.debugLog.AddListener(func() {
+		lines.SetText(t.debugLog.String())
+		scroll.ScrollToBottom()
+	})
+	window.SetOnClosed(stopListening)
+
+	copyBtn := widget.NewButtonWithIcon(CopyLabel, theme.ContentCopyIcon(), func() {
+		window.Clipboard().SetContent(t.debugLog.String())
+	})
+	closeBtn := widget.NewButton(CloseLabel, window.Close)
+
+	window.SetContent(container.NewBorder(nil, container.NewHBox(copyBtn, closeBtn), nil, nil, scroll))
+	window.Resize(fyne.NewSize(debugLogWidth, debugLogHeight))
+	window.Show()
+	scroll.ScrollToBottom()
+}
@@ -0,0 +1,56 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/sharki13/timestamp-converter/logging"
+)
+
+func TestDebugLog(t *testing.T) {
+	converter, _ := newTestConverter(t)
+
+	if !converter.makeInfoMenu().Items[1].Disabled {
+		t.Fatalf("debug log item should be disabled without logging")
+	}
+
+	recent := logging.NewRecent(10)
+	recent.Write([]byte("level=INFO msg=before\n"))
+	converter.SetDebugLog(recent)
+
+	if converter.makeInfoMenu().Items[1].Disabled {
+		t.Fatalf("debug log item should be enabled with logging")
+	}
+
+	converter.showDebugLog()
+
+	// test driver keeps no titles, debug log is the last window
+	windows := converter.app.Driver().AllWindows()
+	window := windows[len(windows)-1]
+
+	var lines *widget.Label
+	for _, o := range test.LaidOutObjects(window.Content()) {
+		if label, ok := o.(*widget.Label); ok && strings.Contains(label.Text, "before") {
+			lines = label
+		}
+	}
+
+	if lines == nil {
+		t.Fatalf("recent lines should be shown")
+	}
+
+	recent.Write([]byte("level=INFO msg=after\n"))
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(lines.Text, "after") {
+		if time.Now().After(deadline) {
+			t.Fatalf("new lines should show up, got %q", lines.Text)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	window.Close()
+}
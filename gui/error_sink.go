@@ -0,0 +1,86 @@
+package gui
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Collects errors which cannot be returned to the caller, like the ones
+// of data listeners, logs them and shows the latest one in a bar
+// at the bottom of the window, the app keeps running
+type errorSink struct {
+	container *fyne.Container
+	label     *widget.Label
+	log       func(msg string, args ...interface{})
+
+	lock   sync.Mutex
+	errors []error
+}
+
+func newErrorSink() *errorSink {
+	sink := &errorSink{
+		label: widget.NewLabel(""),
+		log:   slog.Error,
+	}
+
+	sink.label.Wrapping = fyne.TextTruncate
+
+	dismiss := widget.NewButtonWithIcon("", theme.CancelIcon(), sink.dismiss)
+	dismiss.Importance = widget.LowImportance
+
+	sink.container = container.NewBorder(nil, nil, widget.NewIcon(theme.ErrorIcon()), dismiss, sink.label)
+	sink.container.Hide()
+
+	return sink
+}
+
+// Logs error and shows it, errors reported before the bar
+// is dismissed are counted
+func (s *errorSink) report(err error) {
+	if err == nil {
+		return
+	}
+
+	s.log("error reported", "err", err)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.errors = append(s.errors, err)
+
+	text := err.Error()
+	if len(s.errors) > 1 {
+		text = fmt.Sprintf("%s (%d more)", text, len(s.errors)-1)
+	}
+
+	s.label.SetText(text)
+	s.container.Show()
+}
+
+// Hides the bar and forgets errors shown in it
+func (s *errorSink) dismiss() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.errors = nil
+	s.container.Hide()
+}
+
+// Errors shown in the bar, latest last
+func (s *errorSink) reported() []error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]error{}, s.errors...)
+}
+
+// Reports error which cannot be returned, e.g. from data listener
+func (t *TimestampConverter) reportError(err error) {
+	t.errors.report(err)
+}
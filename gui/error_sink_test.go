@@ -0,0 +1,203 @@
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+)
+
+var errBroken = errors.New("broken binding")
+
+// Bindings which keep listeners working, but cannot be read
+type failingBool struct {
+	binding.Bool
+}
+
+func (failingBool) Get() (bool, error) {
+	return false, errBroken
+}
+
+type failingString struct {
+	binding.String
+}
+
+func (failingString) Get() (string, error) {
+	return "", errBroken
+}
+
+// Collects lines logged by the sink
+type logRecorder struct {
+	lock  sync.Mutex
+	lines []string
+}
+
+func (r *logRecorder) log(msg string, args ...interface{}) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.lines = append(r.lines, fmt.Sprint(append([]interface{}{msg}, args...)...))
+}
+
+func (r *logRecorder) count() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return len(r.lines)
+}
+
+func newTestConverter(t *testing.T) (*TimestampConverter, *logRecorder) {
+	t.Helper()
+
+	converter := NewTimestampConverter(test.NewApp())
+	recorder := &logRecorder{}
+	converter.errors.log = recorder.log
+
+	// listeners still reporting errors would render the error bar
+	// while the next test clears font cache
+	t.Cleanup(func() { waitForListeners(t) })
+
+	return converter, recorder
+}
+
+// Waits for reported errors, listeners of bindings are called asynchronously
+func waitForErrors(t *testing.T, sink *errorSink, count int) []error {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for len(sink.reported()) < count && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	reported := sink.reported()
+	if len(reported) < count {
+		t.Fatalf("expected %d reported errors, got %d", count, len(reported))
+	}
+
+	return reported
+}
+
+func TestErrorSink(t *testing.T) {
+	recorder := &logRecorder{}
+	sink := newErrorSink()
+	sink.log = recorder.log
+
+	sink.report(nil)
+	if sink.container.Visible() || recorder.count() != 0 {
+		t.Fatalf("nil error should be ignored")
+	}
+
+	sink.report(errors.New("first"))
+	if !sink.container.Visible() || sink.label.Text != "first" {
+		t.Fatalf("error should be shown, got visible %v text %q", sink.container.Visible(), sink.label.Text)
+	}
+
+	sink.report(errors.New("second"))
+	if sink.label.Text != "second (1 more)" {
+		t.Fatalf("latest error should be shown with count of earlier ones, got %q", sink.label.Text)
+	}
+
+	if recorder.count() != 2 {
+		t.Fatalf("every error should be logged, got %d lines", recorder.count())
+	}
+
+	sink.dismiss()
+	if sink.container.Visible() || len(sink.reported()) != 0 {
+		t.Fatalf("dismiss should hide the bar and forget errors")
+	}
+}
+
+func TestErrorSink_FailingBindings(t *testing.T) {
+	tests := []struct {
+		name   string
+		inject func(c *TimestampConverter)
+		// triggers code reading the binding, listeners are triggered by adding them
+		trigger func(c *TimestampConverter)
+	}{
+		{
+			name:    "theme menu listener",
+			inject:  func(c *TimestampConverter) { c.theme = failingString{binding.NewString()} },
+			trigger: func(c *TimestampConverter) { c.makeThemeMenu() },
+		},
+		{
+			name:    "format menu listener",
+			inject:  func(c *TimestampConverter) { c.format = failingString{binding.NewString()} },
+			trigger: func(c *TimestampConverter) { c.makeFormatMenu() },
+		},
+		{
+			name:    "bookmarks button",
+			inject:  func(c *TimestampConverter) { c.showBookmarks = failingBool{binding.NewBool()} },
+			trigger: func(c *TimestampConverter) { c.makeBookmarksButton().OnTapped() },
+		},
+		{
+			name:    "toggle menu item",
+			inject:  func(c *TimestampConverter) { c.notifyClipboard = failingBool{binding.NewBool()} },
+			trigger: func(c *TimestampConverter) { c.makeToggleMenuItem(NotifyClipboardLabel, c.notifyClipboard).Action() },
+		},
+		{
+			name:   "clipboard timestamp",
+			inject: func(c *TimestampConverter) { c.detectSerialDates = failingBool{binding.NewBool()} },
+			trigger: func(c *TimestampConverter) {
+				c.onClipboardChanged("2023-01-02T03:04:05Z")
+			},
+		},
+		{
+			name:    "clipboard notification",
+			inject:  func(c *TimestampConverter) { c.format = failingString{binding.NewString()} },
+			trigger: func(c *TimestampConverter) { c.notifyClipboardTimestamp(time.Now(), time.Now()) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter, recorder := newTestConverter(t)
+			tt.inject(converter)
+
+			tt.trigger(converter)
+
+			reported := waitForErrors(t, converter.errors, 1)
+			if !errors.Is(reported[0], errBroken) {
+				t.Fatalf("reported error should be the binding error, got %v", reported[0])
+			}
+
+			if !converter.errors.container.Visible() {
+				t.Fatalf("error bar should be shown")
+			}
+
+			if recorder.count() == 0 {
+				t.Fatalf("error should be logged")
+			}
+		})
+	}
+}
+
+func TestErrorSink_PreferenceErrors(t *testing.T) {
+	converter, _ := newTestConverter(t)
+	release := pauseListeners(t)
+	converter.window.SetContent(converter.makeContent())
+	release()
+	converter.setupAndLoadPreferences()
+	defer converter.stopClipboardWatcher()
+
+	converter.format.Set("not a format")
+
+	reported := waitForErrors(t, converter.errors, 1)
+	if !strings.Contains(reported[0].Error(), "not a format") {
+		t.Fatalf("rejected preference should be reported, got %v", reported[0])
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if format, _ := converter.format.Get(); format == time.RFC3339 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("format should be set back to the fallback")
+}
@@ -1,16 +1,19 @@
 package gui
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	xwidget "fyne.io/x/fyne/widget"
 	"github.com/sharki13/timestamp-converter/timezone"
+	"github.com/sharki13/timestamp-converter/xbinding"
 )
 
 type timestampItemsSet struct {
@@ -31,90 +34,66 @@ func (t *TimestampConverter) makeCopyButtonForEntry(entry *widget.Entry) *widget
 	})
 }
 
-func (t *TimestampConverter) newTimestampSetItems(tz timezone.TimezoneDefinition, window fyne.Window) timestampItemsSet {
-	timestampEntry := widget.NewEntry()
-
-	timestampEntry.OnChanged = func(text string) {
-		timestamp, err := praseStringToTime(text)
-		if err != nil {
-			return
-		}
-
-		currentTimestamp, err := t.timestamp.Get()
-		if err != nil {
-			panic(err)
-		}
-
-		if currentTimestamp != timestamp {
-			t.timestamp.Set(timestamp)
-		}
-	}
+// Formats and parses text of a row, the same way as the timezone is shown
+type rowZone struct {
+	converter *TimestampConverter
+	timezone  timezone.TimezoneDefinition
+}
 
-	timestampEntry.Validator = func(text string) error {
-		_, err := praseStringToTime(text)
-		if err != nil {
-			return err
-		}
+func (z rowZone) Format(t time.Time, layout string) string {
+	return z.timezone.StringTime(t, layout)
+}
 
-		return nil
+func (z rowZone) Parse(text string, _ string) (time.Time, error) {
+	timestamp, err := praseStringToTime(text, z.converter.parseOptions(z.timezone.Type))
+	if err != nil {
+		return time.Time{}, err
 	}
 
-	onFormatOrTimestampChange := binding.NewDataListener(func() {
-		timestamp, err := t.timestamp.Get()
-		if err != nil {
-			panic(err)
-		}
-
-		format, err := t.format.Get()
-		if err != nil {
-			panic(err)
-		}
-
-		new_text := tz.StringTime(timestamp, format)
-
-		if new_text != timestampEntry.Text {
-			timestampEntry.SetText(new_text)
-		}
-	})
+	return timestamp, nil
+}
 
-	t.timestamp.AddListener(onFormatOrTimestampChange)
-	t.format.AddListener(onFormatOrTimestampChange)
+func (t *TimestampConverter) newTimestampSetItems(tz timezone.TimezoneDefinition, window fyne.Window) timestampItemsSet {
+	timeString := xbinding.NewTimeString(&t.timestamp, t.format, rowZone{converter: t, timezone: tz})
+	timeString.OnSet = func(timestamp time.Time) { t.recordHistory(timestamp, historyTyped) }
+	timestampEntry := widget.NewEntryWithData(timeString)
 
+	// local row is always shown
 	visibleState := binding.NewBool()
+	visibleState.Set(tz.Type == timezone.LocalTimezoneType)
 
 	deleteBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
 		visibleState.Set(false)
 
-		for id, state := range t.timezonesVisibleState {
-			visibleIds := make([]int, 0)
+		visibleIds, err := t.visibleTimezones.Get()
+		if err != nil {
+			t.reportError(err)
+			return
+		}
 
-			visible, _ := state.Get()
-			if visible {
-				visibleIds = append(visibleIds, id)
+		// order of the rest is kept
+		remaining := make([]int, 0, len(visibleIds))
+		for _, id := range visibleIds {
+			if id != tz.Id {
+				remaining = append(remaining, id)
 			}
-
-			t.visibleTimezones.Set(visibleIds)
 		}
+
+		t.visibleTimezones.Set(remaining)
 	})
 
 	if tz.Type == timezone.LocalTimezoneType {
 		deleteBtn.Disable()
 	}
 
-	deleteBtnLabelContainer := container.NewHBox(deleteBtn, widget.NewLabel(tz.Label))
+	rowButtons := append([]fyne.CanvasObject{deleteBtn}, t.makeMoveRowButtons(tz.Id)...)
+	deleteBtnLabelContainer := container.NewHBox(append(rowButtons, widget.NewLabel(tz.Label))...)
 
 	entryCopyBtnContainer := container.NewBorder(nil, nil, nil, t.makeCopyButtonForEntry(timestampEntry), timestampEntry)
 
-	visibleHandler := binding.NewDataListener(func() {
-		visible, err := visibleState.Get()
-		if err != nil {
-			panic(err)
-		}
-
-		deleteBtnLabelContainer.Hidden = !visible
-		entryCopyBtnContainer.Hidden = !visible
-	})
-	visibleState.AddListener(visibleHandler)
+	// rows are shown and hidden with the others by arrangeRows
+	deleteBtnLabelContainer.Hidden = tz.Type != timezone.LocalTimezoneType
+	entryCopyBtnContainer.Hidden = tz.Type != timezone.LocalTimezoneType
 
 	return timestampItemsSet{
 		deleteBtnLabelContainer: deleteBtnLabelContainer,
@@ -162,12 +141,17 @@ func (t *TimestampConverter) newTimezoneAddEntry() *xwidget.CompletionEntry {
 				}
 			}
 
-			visibleTimezones := make([]int, 0)
+			visibleTimezones, err := t.visibleTimezones.Get()
+			if err != nil {
+				t.reportError(err)
+				return
+			}
 
-			for k, e := range t.timezonesVisibleState {
-				visible, _ := e.Get()
-				if visible {
-					visibleTimezones = append(visibleTimezones, k)
+			// new row goes to the bottom
+			for _, timeZoneDefinition := range timezone.Timezones {
+				if timeZoneDefinition.Label == entry.Options[0] && !contains(visibleTimezones, timeZoneDefinition.Id) {
+					visibleTimezones = append(orderedVisibleIds(visibleTimezones), timeZoneDefinition.Id)
+					break
 				}
 			}
 
@@ -194,16 +178,17 @@ func (t *TimestampConverter) newTimezoneAddEntry() *xwidget.CompletionEntry {
 
 func (t *TimestampConverter) newToolbar() *fyne.Container {
 	nowBtn := widget.NewButtonWithIcon("Now", theme.ViewRefreshIcon(), func() {
-		t.timestamp.Set(time.Now())
+		t.setTimestamp(time.Now(), historyNow)
 	})
 	nowBtn.Importance = widget.HighImportance
 
 	leftSideToolbarItems := []fyne.CanvasObject{
 		nowBtn,
+		t.makeBookmarksButton(),
 	}
 
 	rightSideToolbarItems := []fyne.CanvasObject{
-		widget.NewCheck("Watch clipboard", func(checked bool) { t.watchClipboard = checked }),
+		widget.NewCheckWithData(WatchClipboardLabel, t.watchClipboard),
 		widget.NewButtonWithIcon("", theme.ContentPasteIcon(), func() {
 			clip := t.window.Clipboard()
 
@@ -216,38 +201,45 @@ func (t *TimestampConverter) newToolbar() *fyne.Container {
 				return
 			}
 
-			timestamp, err := praseStringToTime(clipboardContent)
+			timestamp, err := praseStringToTime(clipboardContent, t.parseOptions(timezone.LocalTimezoneType))
 			if err != nil {
+				dialog.ShowError(fmt.Errorf("cannot paste %q: %w", clipboardContent, err), t.window)
 				return
 			}
 
-			t.timestamp.Set(timestamp)
+			t.setTimestamp(timestamp, historyPasted)
 		}),
 	}
 
 	return container.NewBorder(nil, nil, container.NewHBox(leftSideToolbarItems...), container.NewHBox(rightSideToolbarItems...), t.newTimezoneAddEntry())
 }
 
-func (t *TimestampConverter) makeContent() *fyne.Container {
+func (t *TimestampConverter) makeContent() fyne.CanvasObject {
 	leftSide := container.NewVBox()
 	middle := container.NewVBox()
 
 	for _, tz := range timezone.Timezones {
 		items := t.newTimestampSetItems(tz, t.window)
+		t.rows[tz.Id] = items
 
 		leftSide.Add(items.deleteBtnLabelContainer)
 		middle.Add(items.entryCopyBtnContainer)
 
-		if tz.Type == timezone.LocalTimezoneType {
-			items.visible.Set(true)
-		} else {
-			items.visible.Set(false)
-		}
-
 		// add to visible changer
 		t.timezonesVisibleState[tz.Id] = items.visible
 	}
 
+	t.rowLabels = leftSide
+	t.rowEntries = middle
+
+	// rows follow the list, so imported settings show up right away,
+	// they are shown before the window is laid out, the listener shows changes
+	t.showVisibleTimezones()
+	t.visibleTimezones.AddListener(binding.NewDataListener(t.showVisibleTimezones))
+
 	scrollableMiddle := container.NewVScroll(container.NewBorder(nil, nil, leftSide, nil, middle))
-	return container.NewBorder(t.newToolbar(), nil, nil, nil, scrollableMiddle)
+	bottom := container.NewVBox(t.errors.container, t.makeClipboardToast())
+	content := container.NewBorder(t.newToolbar(), bottom, nil, t.makeBookmarksPanel(), scrollableMiddle)
+
+	return t.watchWindowState(content, scrollableMiddle)
 }
@@ -1,29 +1,231 @@
 package gui
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/sharki13/timestamp-converter/timezone"
+)
+
+// Options which tweak how praseStringToTime interprets its input
+type parseOptions struct {
+	// treat plain numbers as Excel 1900 serial dates,
+	// unless they are too big to be one or typed into Unix row
+	detectSerialDates bool
+	// row type the text comes from, number rows always parse numbers
+	// in their own date system, time scale rows are converted to UTC
+	rowType timezone.TimezoneType
+}
+
+// Names of detectors as listed in ParseError
+const (
+	rowNumberDetector  = "number of this row"
+	serialDateDetector = "Excel serial date"
+	julianDateDetector = "Julian Date"
+	unixDetector       = "Unix timestamp"
 )
 
-func praseStringToTime(s string) (time.Time, error) {
+// Parses text as timestamp, result has to fit into range supported by timezone package
+// Returned error is *ParseError
+func praseStringToTime(s string, opts parseOptions) (time.Time, error) {
+	t, detector, err := detectTime(s, opts)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !timezone.InRange(t) {
+		return time.Time{}, &ParseError{
+			Input:    s,
+			Tried:    []string{detector},
+			Closest:  detector,
+			Reason:   outOfRangeReason(),
+			Position: -1,
+		}
+	}
+
+	return t, nil
+}
+
+func outOfRangeReason() string {
+	return fmt.Sprintf("out of supported range from %s to %s",
+		timezone.MinTime.Format(time.RFC3339),
+		timezone.MaxTime.Format(time.RFC3339))
+}
+
+// Formats sorted by label, so detectors are always tried in the same order
+func sortedFormats() []string {
+	formats := make([]string, 0, len(FormatLabelMap))
 	for format := range FormatLabelMap {
+		formats = append(formats, format)
+	}
+
+	sort.Slice(formats, func(i, j int) bool {
+		return FormatLabelMap[formats[i]] < FormatLabelMap[formats[j]]
+	})
+
+	return formats
+}
+
+// Tries all detectors in order, returns time and name of detector which recognized it
+func detectTime(s string, opts parseOptions) (time.Time, string, error) {
+	tried := make([]string, 0)
+	candidates := make([]parseCandidate, 0)
+
+	// number which failed in a numeric detector is out of its range,
+	// that is the most specific thing to tell
+	_, numberErr := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	isNumber := numberErr == nil
+
+	numberCandidate := func(detector string, err error) {
+		if isNumber {
+			reason := err.Error()
+			if errors.Is(err, timezone.ErrOutOfRange) {
+				reason = outOfRangeReason()
+			}
+
+			candidates = append(candidates, parseCandidate{
+				detector: detector,
+				reason:   reason,
+				position: -1,
+				score:    len(s) + 1,
+			})
+		}
+	}
+
+	if timezone.IsNumber(opts.rowType) {
+		tried = append(tried, rowNumberDetector)
+		t, err := timezone.ParseNumber(s, opts.rowType)
+		if err == nil {
+			return t, rowNumberDetector, nil
+		}
+		numberCandidate(rowNumberDetector, err)
+	} else if opts.detectSerialDates && opts.rowType != timezone.UnixTimezoneType {
+		tried = append(tried, serialDateDetector)
+		t, err := timezone.ParseSerial(s, timezone.ExcelSerial1900TimezoneType)
+		if err == nil {
+			return t, serialDateDetector, nil
+		}
+
+		// bigger whole numbers are Unix timestamps, others are told
+		// why they are not a serial date
+		if _, intErr := strconv.ParseInt(s, 10, 64); intErr != nil {
+			numberCandidate(serialDateDetector, err)
+		}
+	}
+
+	tried = append(tried, julianDateDetector)
+	t, err := timezone.ParseJulian(s)
+	if err == nil {
+		return t, julianDateDetector, nil
+	}
+
+	if !errors.Is(err, timezone.ErrNoJulianPrefix) {
+		reason := "invalid number after JD or MJD prefix"
+		if errors.Is(err, timezone.ErrOutOfRange) {
+			reason = outOfRangeReason()
+		}
+
+		candidates = append(candidates, parseCandidate{
+			detector: julianDateDetector,
+			reason:   reason,
+			position: -1,
+			score:    len(s) + 1,
+		})
+	}
+
+	for _, format := range sortedFormats() {
+		label := FormatLabelMap[format]
+		tried = append(tried, label)
+
+		// time scale rows show name of the scale instead of zone,
+		// scales have no leap seconds
+		if scaled := timezone.ScaleLayout(format, opts.rowType); scaled != format {
+			if t, err := time.Parse(scaled, s); err == nil {
+				return timezone.ScaleToUTC(t, opts.rowType), label, nil
+			}
+		}
+
 		t, err := time.Parse(format, s)
 		if err == nil {
-			if t.Unix() >= 0 && t.Unix() <= 253374914595 {
-				return t, nil
-			} else {
-				return t, fmt.Errorf("invalid time format")
+			return timezone.ScaleToUTC(t, opts.rowType), label, nil
+		}
+
+		// time.Parse does not know 23:59:60
+		leapT, leapErr := timezone.ParseLeapSecond(format, s)
+		if leapErr == nil {
+			return timezone.ScaleToUTC(leapT, opts.rowType), label, nil
+		}
+
+		var parseErr *time.ParseError
+		if errors.Is(leapErr, timezone.ErrNoLeapSecondField) || errors.As(leapErr, &parseErr) {
+			if errors.As(err, &parseErr) {
+				candidates = append(candidates, candidateFromTimeParseError(label, format, s, parseErr))
 			}
+		} else {
+			// format matched, but there was no such leap second
+			candidates = append(candidates, parseCandidate{
+				detector: label,
+				reason:   leapErr.Error(),
+				position: -1,
+				score:    len(s) + 1,
+			})
 		}
 	}
 
+	tried = append(tried, unixDetector)
 	intT, err := strconv.ParseInt(s, 10, 64)
-	if err == nil && intT >= 0 && intT <= 253374914595 {
-		return time.Unix(intT, 0), nil
+	if err == nil && intT >= timezone.MinTime.Unix() && intT <= timezone.MaxTime.Unix() {
+		return time.Unix(intT, 0), unixDetector, nil
+	}
+
+	if err == nil || errors.Is(err, strconv.ErrRange) {
+		candidates = append(candidates, parseCandidate{
+			detector: unixDetector,
+			reason:   outOfRangeReason(),
+			position: -1,
+			score:    len(s) + 2,
+		})
+	}
+
+	parseErr := &ParseError{
+		Input:    s,
+		Tried:    tried,
+		Position: -1,
+	}
+
+	best := -1
+	for i, c := range candidates {
+		if best < 0 || c.score > candidates[best].score {
+			best = i
+		}
 	}
 
-	return time.Time{}, fmt.Errorf("invalid time format")
+	// position 0 means nothing matched, that is not close at all
+	if best >= 0 && candidates[best].score > 0 {
+		parseErr.Closest = candidates[best].detector
+		parseErr.Reason = candidates[best].reason
+		parseErr.Position = candidates[best].position
+	}
+
+	return time.Time{}, "", parseErr
+}
+
+// Returns parse options for text coming from row of given type
+func (t *TimestampConverter) parseOptions(rowType timezone.TimezoneType) parseOptions {
+	// serial dates are not detected when the setting cannot be read
+	detectSerialDates, err := t.detectSerialDates.Get()
+	if err != nil {
+		t.reportError(err)
+	}
+
+	return parseOptions{
+		detectSerialDates: detectSerialDates,
+		rowType:           rowType,
+	}
 }
 
 func contains[K comparable](s []K, e K) bool {
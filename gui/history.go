@@ -0,0 +1,111 @@
+package gui
+
+import (
+	"sync"
+	"time"
+)
+
+// How many timestamps history keeps, the oldest ones are dropped
+const historyLimit = 100
+
+// Where timestamp came from, shown in history panel
+const (
+	historyTyped     = "typed"
+	historyPasted    = "pasted"
+	historyClipboard = "detected from clipboard"
+	historyNow       = "now"
+	historyRestored  = "restored"
+	historyBookmark  = "bookmark"
+)
+
+type historyEntry struct {
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"`
+}
+
+// Bounded list of timestamps with position for undo and redo,
+// recording after undo drops entries which could be redone
+type history struct {
+	lock    sync.Mutex
+	entries []historyEntry
+	// index of the current entry, -1 when empty
+	position int
+	limit    int
+}
+
+func newHistory(limit int) *history {
+	return &history{
+		position: -1,
+		limit:    limit,
+	}
+}
+
+// Adds entry after the current one, returns false if it is the current
+// timestamp already, e.g. set by undo
+func (h *history) record(entry historyEntry) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.position >= 0 && h.entries[h.position].Time.Equal(entry.Time) {
+		return false
+	}
+
+	h.entries = append(h.entries[:h.position+1], entry)
+
+	if len(h.entries) > h.limit {
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+
+	h.position = len(h.entries) - 1
+
+	return true
+}
+
+// Moves to the previous entry and returns it, false if there is none
+func (h *history) undo() (historyEntry, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.position <= 0 {
+		return historyEntry{}, false
+	}
+
+	h.position--
+
+	return h.entries[h.position], true
+}
+
+// Moves to the next entry and returns it, false if there is none
+func (h *history) redo() (historyEntry, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.position < 0 || h.position >= len(h.entries)-1 {
+		return historyEntry{}, false
+	}
+
+	h.position++
+
+	return h.entries[h.position], true
+}
+
+// Returns copy of all entries, the oldest first
+func (h *history) list() []historyEntry {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return append([]historyEntry{}, h.entries...)
+}
+
+// Replaces entries, e.g. with ones saved before restart, the last one is current
+func (h *history) load(entries []historyEntry) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if len(entries) > h.limit {
+		entries = entries[len(entries)-h.limit:]
+	}
+
+	h.entries = append([]historyEntry{}, entries...)
+	h.position = len(h.entries) - 1
+}
@@ -0,0 +1,159 @@
+package gui
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+	prefSync "github.com/sharki13/timestamp-converter/preferences"
+)
+
+const historyLayout = "2006-01-02 15:04:05 MST"
+
+// Sets timestamp and records it in history with where it came from,
+// entries bound to the timestamp record typed ones themselves
+func (t *TimestampConverter) setTimestamp(timestamp time.Time, source string) {
+	slog.Debug("timestamp set", "timestamp", timestamp, "source", source)
+	t.timestamp.Set(timestamp)
+	t.recordHistory(timestamp, source)
+}
+
+// Recorded right when timestamp is set, listeners are called later
+// and would see only the last of quickly set timestamps
+func (t *TimestampConverter) recordHistory(timestamp time.Time, source string) {
+	if t.history.record(historyEntry{Time: timestamp, Source: source}) {
+		t.saveHistory()
+	}
+}
+
+// Registers history preferences and starts recording timestamp changes
+func (t *TimestampConverter) setupHistory() {
+	err := t.preferences.AddBool(prefSync.BoolPreference{
+		Key:      "keepHistory",
+		Value:    t.keepHistory,
+		Fallback: false,
+	})
+
+	if err != nil {
+		t.reportError(err)
+	}
+
+	err = prefSync.Add[[]historyEntry](t.preferences, prefSync.Preference[[]historyEntry]{
+		Key:      "history",
+		Value:    &t.savedHistory,
+		Fallback: []historyEntry{},
+		Codec:    prefSync.JSONCodec[[]historyEntry]{},
+	})
+
+	if err != nil {
+		t.reportError(err)
+	}
+
+	keep, err := t.keepHistory.Get()
+	if err != nil {
+		t.reportError(err)
+	}
+
+	if keep {
+		saved, err := t.savedHistory.Get()
+		if err != nil {
+			t.reportError(err)
+		} else {
+			t.history.load(saved)
+		}
+	}
+
+	// timestamp set before the saved history was loaded is the current one
+	timestamp, err := t.timestamp.Get()
+	if err != nil {
+		t.reportError(err)
+	} else {
+		t.recordHistory(timestamp, historyNow)
+	}
+
+	// history is stored only while user wants it
+	t.keepHistory.AddListener(binding.NewDataListener(t.saveHistory))
+}
+
+func (t *TimestampConverter) saveHistory() {
+	t.historySaveLock.Lock()
+	defer t.historySaveLock.Unlock()
+
+	keep, err := t.keepHistory.Get()
+	if err != nil {
+		t.reportError(err)
+		return
+	}
+
+	if !keep {
+		t.savedHistory.Set([]historyEntry{})
+		return
+	}
+
+	t.savedHistory.Set(t.history.list())
+}
+
+func (t *TimestampConverter) undoTimestamp() {
+	if entry, ok := t.history.undo(); ok {
+		t.timestamp.Set(entry.Time)
+	}
+}
+
+func (t *TimestampConverter) redoTimestamp() {
+	if entry, ok := t.history.redo(); ok {
+		t.timestamp.Set(entry.Time)
+	}
+}
+
+func (t *TimestampConverter) makeEditMenu() *fyne.Menu {
+	undo := fyne.NewMenuItem(UndoLabel, t.undoTimestamp)
+	undo.Shortcut = &desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: fyne.KeyModifierShortcutDefault}
+
+	redo := fyne.NewMenuItem(RedoLabel, t.redoTimestamp)
+	redo.Shortcut = &desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: fyne.KeyModifierShortcutDefault | fyne.KeyModifierShift}
+
+	// menu shortcuts are not registered on every platform, so canvas gets them too
+	t.window.Canvas().AddShortcut(undo.Shortcut, func(fyne.Shortcut) { t.undoTimestamp() })
+	t.window.Canvas().AddShortcut(redo.Shortcut, func(fyne.Shortcut) { t.redoTimestamp() })
+
+	return fyne.NewMenu(EditLabel,
+		undo,
+		redo,
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(HistoryLabel, t.showHistoryDialog),
+		t.makeToggleMenuItem(KeepHistoryLabel, t.keepHistory),
+	)
+}
+
+// Lists recent timestamps, the newest first, selected one becomes current
+func (t *TimestampConverter) showHistoryDialog() {
+	entries := t.history.list()
+
+	list := widget.NewList(
+		func() int {
+			return len(entries)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("0000-00-00 00:00:00 MST (detected from clipboard)")
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			entry := entries[len(entries)-1-id]
+			o.(*widget.Label).SetText(fmt.Sprintf("%s (%s)", entry.Time.Local().Format(historyLayout), entry.Source))
+		},
+	)
+
+	d := dialog.NewCustom(HistoryLabel, CloseLabel, list, t.window)
+
+	list.OnSelected = func(id widget.ListItemID) {
+		t.setTimestamp(entries[len(entries)-1-id].Time, historyRestored)
+		d.Hide()
+	}
+
+	d.Resize(fyne.NewSize(450, 400))
+	d.Show()
+}
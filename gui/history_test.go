@@ -0,0 +1,104 @@
+package gui
+
+import (
+	"testing"
+	"time"
+)
+
+func at(second int) time.Time {
+	return time.Date(2023, 3, 14, 15, 9, second, 0, time.UTC)
+}
+
+func TestHistory_UndoRedo(t *testing.T) {
+	h := newHistory(historyLimit)
+
+	if _, ok := h.undo(); ok {
+		t.Errorf("undo() on empty history should fail")
+	}
+
+	for i := 1; i <= 3; i++ {
+		if !h.record(historyEntry{Time: at(i), Source: historyTyped}) {
+			t.Errorf("record(%d) should add entry", i)
+		}
+	}
+
+	if h.record(historyEntry{Time: at(3), Source: historyPasted}) {
+		t.Errorf("record() of current timestamp should not add entry")
+	}
+
+	tests := []struct {
+		name   string
+		action func() (historyEntry, bool)
+		want   time.Time
+		wantOk bool
+	}{
+		{"Undo", h.undo, at(2), true},
+		{"Undo_Again", h.undo, at(1), true},
+		{"Undo_AtStart", h.undo, time.Time{}, false},
+		{"Redo", h.redo, at(2), true},
+		{"Redo_Again", h.redo, at(3), true},
+		{"Redo_AtEnd", h.redo, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		entry, ok := tt.action()
+		if ok != tt.wantOk {
+			t.Errorf("%s: ok = %v, want %v", tt.name, ok, tt.wantOk)
+		}
+
+		if ok && !entry.Time.Equal(tt.want) {
+			t.Errorf("%s: time = %v, want %v", tt.name, entry.Time, tt.want)
+		}
+	}
+}
+
+func TestHistory_RecordAfterUndoDropsRedo(t *testing.T) {
+	h := newHistory(historyLimit)
+
+	h.record(historyEntry{Time: at(1)})
+	h.record(historyEntry{Time: at(2)})
+	h.undo()
+
+	// timestamp set by undo comes back through the listener
+	if h.record(historyEntry{Time: at(1)}) {
+		t.Errorf("record() of timestamp set by undo should not add entry")
+	}
+
+	h.record(historyEntry{Time: at(5)})
+
+	if _, ok := h.redo(); ok {
+		t.Errorf("redo() should fail after new entry was recorded")
+	}
+
+	entries := h.list()
+	if len(entries) != 2 || !entries[1].Time.Equal(at(5)) {
+		t.Errorf("list() = %v, want entries of second 1 and 5", entries)
+	}
+}
+
+func TestHistory_Limit(t *testing.T) {
+	h := newHistory(3)
+
+	for i := 1; i <= 5; i++ {
+		h.record(historyEntry{Time: at(i)})
+	}
+
+	entries := h.list()
+	if len(entries) != 3 {
+		t.Fatalf("len(list()) = %d, want 3", len(entries))
+	}
+
+	if !entries[0].Time.Equal(at(3)) {
+		t.Errorf("oldest entry = %v, want %v", entries[0].Time, at(3))
+	}
+
+	h.load([]historyEntry{{Time: at(1)}, {Time: at(2)}, {Time: at(3)}, {Time: at(4)}})
+
+	entries = h.list()
+	if len(entries) != 3 || !entries[0].Time.Equal(at(2)) {
+		t.Errorf("load() should keep only the newest entries, got %v", entries)
+	}
+
+	if entry, ok := h.undo(); !ok || !entry.Time.Equal(at(3)) {
+		t.Errorf("undo() after load() = %v, %v, want %v", entry.Time, ok, at(3))
+	}
+}
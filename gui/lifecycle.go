@@ -1,100 +1,252 @@
 package gui
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
 	"time"
 
 	"fyne.io/fyne/v2/data/binding"
 	prefSync "github.com/sharki13/timestamp-converter/preferences"
+	"github.com/sharki13/timestamp-converter/timezone"
 	"github.com/sharki13/timestamp-converter/xbinding"
 )
 
+// How long preference writes wait for further changes,
+// live clock or quick edits would write on every change otherwise
+const preferencesDebounce = 500 * time.Millisecond
+
+// Keys of preferences chosen in menus, they are reset and exported as settings,
+// user data like history and bookmarks and window state are left alone
+// Bookmarks are exported on their own from the bookmarks panel
+var settingsKeys = []string{
+	"format",
+	"theme",
+	"detectSerialDates",
+	"watchClipboard",
+	"notifyClipboard",
+	"visibleTimezones",
+	prefSync.ProfilesKey,
+	prefSync.ActiveProfileKey,
+}
+
 // Sets up the preferences and loads them
 // from the fyne preferences
-// Should be called just before ShowAndRun
+// Should be called before menu and content are made
 func (t *TimestampConverter) setupAndLoadPreferences() {
+	t.preferences.SetErrorHandler(t.reportError)
+
 	err := t.preferences.AddString(prefSync.StringPreference{
 		Key:      "format",
 		Value:    t.format,
 		Fallback: time.RFC3339,
+		Validate: validateFormat,
 	})
 
 	if err != nil {
-		panic(err)
+		t.reportError(err)
 	}
 
 	err = t.preferences.AddString(prefSync.StringPreference{
 		Key:      "theme",
 		Value:    t.theme,
 		Fallback: SystemTheme,
+		Validate: prefSync.OneOf(SystemTheme, LightTheme, DarkTheme),
+	})
+
+	if err != nil {
+		t.reportError(err)
+	}
+
+	err = t.preferences.AddBool(prefSync.BoolPreference{
+		Key:      "detectSerialDates",
+		Value:    t.detectSerialDates,
+		Fallback: false,
+	})
+
+	if err != nil {
+		t.reportError(err)
+	}
+
+	err = t.preferences.AddBool(prefSync.BoolPreference{
+		Key:      "watchClipboard",
+		Value:    t.watchClipboard,
+		Fallback: false,
+	})
+
+	if err != nil {
+		t.reportError(err)
+	}
+
+	err = t.preferences.AddBool(prefSync.BoolPreference{
+		Key:      "notifyClipboard",
+		Value:    t.notifyClipboard,
+		Fallback: false,
 	})
 
 	if err != nil {
-		panic(err)
+		t.reportError(err)
 	}
 
 	err = t.preferences.AddIntArray(prefSync.IntArrayPreference{
-		Key:      "visibleTimezones",
-		Value:    t.visibleTimezones,
-		Fallback: []int{0},
+		Key:       "visibleTimezones",
+		Value:     t.visibleTimezones,
+		Fallback:  []int{0},
+		Normalize: normalizeVisibleTimezones,
 	})
 
 	if err != nil {
-		panic(err)
+		t.reportError(err)
 	}
 
-	savedTimezones, err := t.visibleTimezones.Get()
-	for _, timezoneIndex := range savedTimezones {
-		if visible, ok := t.timezonesVisibleState[timezoneIndex]; ok {
-			visible.Set(true)
+	t.setupWindowStatePreferences()
+	t.setupHistory()
+	t.setupBookmarks()
+
+	t.profiles, err = prefSync.NewProfiles(t.preferences, []string{
+		"format",
+		"theme",
+		"detectSerialDates",
+		"visibleTimezones",
+	})
+
+	// profile menu stays empty without profiles
+	if err != nil {
+		t.reportError(err)
+	} else {
+		t.profiles.AddListener(binding.NewDataListener(t.refreshProfileMenu))
+	}
+
+	t.watchClipboard.AddListener(binding.NewDataListener(func() {
+		watch, err := t.watchClipboard.Get()
+		if err != nil {
+			t.reportError(err)
+			return
 		}
+
+		t.clipboardWatcher.SetEnabled(watch)
+	}))
+
+	// clipboard is watched until the app stops
+	ctx, cancel := context.WithCancel(context.Background())
+	t.stopClipboardWatcher = cancel
+	go t.clipboardWatcher.Run(ctx)
+}
+
+// Shows rows of visible timezones, order of the list is the order of rows
+func (t *TimestampConverter) showVisibleTimezones() {
+	savedTimezones, err := t.visibleTimezones.Get()
+	if err != nil {
+		t.reportError(err)
+		return
 	}
 
+	for id, visible := range t.timezonesVisibleState {
+		visible.Set(id == timezone.Local || contains(savedTimezones, id))
+	}
+
+	t.arrangeRows(savedTimezones)
+}
+
+// Sets timestamp to the one copied to clipboard, other content is ignored
+func (t *TimestampConverter) onClipboardChanged(content string) {
+	// clipboard may hold passwords, so only its length gets to the log
+	timestamp, err := praseStringToTime(content, t.parseOptions(timezone.LocalTimezoneType))
 	if err != nil {
-		panic(err)
+		slog.Debug("clipboard content is not a timestamp", "length", len(content), "err", err)
+		return
 	}
 
-	// run background loop to watch for clipboard changes
-	go func() {
-		for {
-			time.Sleep(time.Second)
-			if t.watchClipboard {
-				clip := t.window.Clipboard()
+	slog.Debug("timestamp detected in clipboard", "length", len(content), "timestamp", timestamp)
 
-				if clip == nil {
-					continue
-				}
+	currentTimestamp, err := t.timestamp.Get()
+	if err != nil {
+		t.reportError(err)
+		return
+	}
 
-				cliboardContent := clip.Content()
-				if cliboardContent == "" {
-					continue
-				}
+	if timestamp == currentTimestamp {
+		return
+	}
 
-				timestamp, err := praseStringToTime(cliboardContent)
-				if err != nil {
-					continue
-				}
+	t.setTimestamp(timestamp, historyClipboard)
+	t.notifyClipboardTimestamp(timestamp, currentTimestamp)
+}
 
-				currentTimestamp, err := t.timestamp.Get()
-				if err != nil {
-					panic(err)
-				}
+// Only formats offered in the menu are accepted
+func validateFormat(format string) error {
+	if _, ok := FormatLabelMap[format]; !ok {
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	return nil
+}
 
-				if timestamp == currentTimestamp {
-					continue
-				}
+// Drops ids of timezones which do not exist and duplicates
+func normalizeVisibleTimezones(ids []int) []int {
+	known := make(map[int]bool, len(timezone.Timezones))
+	for _, tz := range timezone.Timezones {
+		known[tz.Id] = true
+	}
 
-				t.timestamp.Set(timestamp)
-			}
+	normalized := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if known[id] && !contains(normalized, id) {
+			normalized = append(normalized, id)
 		}
-	}()
+	}
+
+	return normalized
 }
 
 func (t *TimestampConverter) initialize() {
+	t.errors = newErrorSink()
 	t.timezonesVisibleState = make(map[int]binding.Bool)
+	t.rows = make(map[int]timestampItemsSet)
+	t.windowWidth = binding.NewFloat()
+	t.windowHeight = binding.NewFloat()
+	t.scrollOffset = binding.NewFloat()
 	t.visibleTimezones = xbinding.NewIntArray()
 	t.timestamp = xbinding.NewTime()
-	t.timestamp.Set(time.Now())
+	t.history = newHistory(historyLimit)
+	t.savedHistory = xbinding.NewList[historyEntry]()
+	t.keepHistory = binding.NewBool()
+	t.bookmarks = xbinding.NewList[bookmark]()
+	t.showBookmarks = binding.NewBool()
+	t.setTimestamp(time.Now(), historyNow)
 	t.format = binding.NewString()
+	t.detectSerialDates = binding.NewBool()
+	t.watchClipboard = binding.NewBool()
+	t.notifyClipboard = binding.NewBool()
 	t.theme = binding.NewString()
-	t.preferences = prefSync.NewPreferencesSynchronizer(t.app)
+	t.preferences = t.newPreferencesSynchronizer()
+	t.preferences.SetDebounce(preferencesDebounce)
+	t.clipboardWatcher = newClipboardWatcher(t.window.Clipboard(), clipboardWatchInterval, t.onClipboardChanged)
+
+	t.app.Lifecycle().SetOnStopped(t.stop)
+}
+
+// Stops clipboard watcher and stores writes waiting for debounce,
+// they would be lost otherwise
+func (t *TimestampConverter) stop() {
+	if t.stopClipboardWatcher != nil {
+		t.stopClipboardWatcher()
+	}
+
+	if err := t.preferences.Flush(); err != nil {
+		t.reportError(err)
+	}
+}
+
+// Stored preferences which cannot be migrated are left untouched,
+// settings of this run are kept in memory only
+func (t *TimestampConverter) newPreferencesSynchronizer() *prefSync.PreferencesSynchronizer {
+	preferences, err := prefSync.NewPreferencesSynchronizerWithMigrations(t.app, prefSync.DefaultMigrations)
+	if err == nil {
+		return preferences
+	}
+
+	t.reportError(fmt.Errorf("preferences will not be saved: %w", err))
+
+	return prefSync.NewMemoryPreferencesSynchronizer(prefSync.DefaultMigrations)
 }
@@ -6,25 +6,36 @@ import (
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/data/binding"
-	"fyne.io/fyne/v2/theme"
 )
 
 func (t *TimestampConverter) makeMenu() *fyne.MainMenu {
 	menus := make([]*fyne.Menu, 0)
 
+	fileMenu := fyne.NewMenu(FileLabel,
+		fyne.NewMenuItem(ExportSettingsLabel, t.showExportSettingsDialog),
+		fyne.NewMenuItem(ImportSettingsLabel, t.showImportSettingsDialog),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(ResetSettingsLabel, t.confirmResetSettings),
+	)
+
 	// Mac OS has a built in quit menu,
 	// on other platforms Fyne will add Quit to first menu if it is not defined
 	if runtime.GOOS != "darwin" {
-		fileMenu := fyne.NewMenu(FileLabel, fyne.NewMenuItem(QuitLabel, func() {
-			t.app.Quit()
-		}))
-
-		menus = append(menus, fileMenu)
+		fileMenu.Items = append(fileMenu.Items,
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem(QuitLabel, func() {
+				t.app.Quit()
+			}))
 	}
 
+	menus = append(menus, fileMenu)
+
 	menus = append(menus,
+		t.makeEditMenu(),
+		t.makeProfileMenu(),
 		t.makeFormatMenu(),
 		t.makeThemeMenu(),
+		t.makeToolsMenu(),
 		t.makeInfoMenu(),
 	)
 
@@ -37,7 +48,10 @@ func (t *TimestampConverter) makeInfoMenu() *fyne.Menu {
 		_ = t.app.OpenURL(u)
 	})
 
-	return fyne.NewMenu(HelpLabel, about)
+	debugLog := fyne.NewMenuItem(ShowDebugLogLabel, t.showDebugLog)
+	debugLog.Disabled = t.debugLog == nil
+
+	return fyne.NewMenu(HelpLabel, about, debugLog)
 }
 
 func (t *TimestampConverter) makeThemeMenu() *fyne.Menu {
@@ -60,26 +74,28 @@ func (t *TimestampConverter) makeThemeMenu() *fyne.Menu {
 	t.theme.AddListener(binding.NewDataListener(func() {
 		themeVariant, err := t.theme.Get()
 		if err != nil {
-			panic(err)
+			t.reportError(err)
+			return
+		}
+
+		// empty until preferences are loaded, applying system theme
+		// before that would switch the theme twice on start
+		if themeVariant == "" {
+			return
 		}
 
+		t.applyTheme(themeVariantOf(t.app.Settings(), themeVariant))
+
 		switch themeVariant {
 		case LightTheme:
-			t.app.Settings().SetTheme(&myTheme{variant: LightTheme})
 			light.Checked = true
 			dark.Checked = false
 			system.Checked = false
 		case DarkTheme:
-			t.app.Settings().SetTheme(&myTheme{variant: DarkTheme})
 			light.Checked = false
 			dark.Checked = true
 			system.Checked = false
 		default:
-			if t.app.Settings().ThemeVariant() == theme.VariantLight {
-				t.app.Settings().SetTheme(&myTheme{variant: LightTheme})
-			} else {
-				t.app.Settings().SetTheme(&myTheme{variant: DarkTheme})
-			}
 			light.Checked = false
 			dark.Checked = false
 			system.Checked = true
@@ -102,15 +118,22 @@ func (t *TimestampConverter) makeFormatMenu() *fyne.Menu {
 		formatMenu.Items = append(formatMenu.Items, formatMenuItem)
 	}
 
+	formatMenu.Items = append(formatMenu.Items, fyne.NewMenuItemSeparator(), t.makeDetectSerialDatesMenuItem())
+
 	t.format.AddListener(binding.NewDataListener(func() {
 		currentFormat, err := t.format.Get()
 		if err != nil {
-			panic(err)
+			t.reportError(err)
+			return
 		}
 
 		label := FormatLabelMap[currentFormat]
 
 		for _, item := range formatMenu.Items {
+			if item.IsSeparator || item.Label == DetectSerialDatesLabel {
+				continue
+			}
+
 			if item.Label == label {
 				item.Checked = true
 			} else {
@@ -121,3 +144,36 @@ func (t *TimestampConverter) makeFormatMenu() *fyne.Menu {
 
 	return formatMenu
 }
+
+// Opt-in for treating plain numbers as spreadsheet serial dates,
+// off by default since they are easy to confuse with Unix timestamps
+func (t *TimestampConverter) makeDetectSerialDatesMenuItem() *fyne.MenuItem {
+	return t.makeToggleMenuItem(DetectSerialDatesLabel, t.detectSerialDates)
+}
+
+// Menu item which flips the value and is checked while it is true
+func (t *TimestampConverter) makeToggleMenuItem(label string, value binding.Bool) *fyne.MenuItem {
+	item := fyne.NewMenuItem(label, nil)
+
+	item.Action = func() {
+		current, err := value.Get()
+		if err != nil {
+			t.reportError(err)
+			return
+		}
+
+		value.Set(!current)
+	}
+
+	value.AddListener(binding.NewDataListener(func() {
+		current, err := value.Get()
+		if err != nil {
+			t.reportError(err)
+			return
+		}
+
+		item.Checked = current
+	}))
+
+	return item
+}
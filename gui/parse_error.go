@@ -0,0 +1,184 @@
+package gui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Error returned by praseStringToTime, tells what was tried
+// and why the input did not match the format closest to it
+type ParseError struct {
+	Input string
+	// names of detectors which were tried, in order
+	Tried []string
+	// detector which got furthest into the input, empty if none did
+	Closest string
+	// what is wrong with the input, e.g. "month 13 out of range"
+	Reason string
+	// byte offset in Input where the closest detector failed, -1 if unknown
+	Position int
+}
+
+func (e *ParseError) Error() string {
+	if e.Closest == "" {
+		return fmt.Sprintf("not a timestamp, tried: %s", strings.Join(e.Tried, ", "))
+	}
+
+	if e.Position < 0 {
+		return fmt.Sprintf("%s, closest format: %s", e.Reason, e.Closest)
+	}
+
+	return fmt.Sprintf("%s at position %d, closest format: %s", e.Reason, e.Position+1, e.Closest)
+}
+
+// Failure of a single detector, kept to pick the closest one
+type parseCandidate struct {
+	detector string
+	reason   string
+	position int
+	// how far the detector got, highest one wins, input which was
+	// fully recognized but is invalid scores above its length
+	score int
+}
+
+// Names of layout elements as shown to the user
+var layoutElementNames = map[string]string{
+	"2006":       "year",
+	"06":         "year",
+	"01":         "month",
+	"Jan":        "month name",
+	"January":    "month name",
+	"02":         "day",
+	"_2":         "day",
+	"Mon":        "weekday",
+	"Monday":     "weekday",
+	"15":         "hour",
+	"04":         "minute",
+	"05":         "second",
+	"MST":        "zone name",
+	"-0700":      "zone offset",
+	"-07:00":     "zone offset",
+	"Z07:00":     "zone offset",
+	"Z0700":      "zone offset",
+	"-07":        "zone offset",
+	".000":       "fraction of second",
+	".999999999": "fraction of second",
+}
+
+// Patterns of layout elements, longest ones first so "January" wins over "Jan"
+var layoutElementPatterns = []struct {
+	element string
+	pattern string
+}{
+	{"January", `[A-Za-z]+`},
+	{"Monday", `[A-Za-z]+`},
+	{"Z07:00", `(?:Z|[+-]\d{2}:\d{2})`},
+	{"-07:00", `[+-]\d{2}:\d{2}`},
+	{"Z0700", `(?:Z|[+-]\d{4})`},
+	{"-0700", `[+-]\d{4}`},
+	{"2006", `[+-]?\d{4}`},
+	{"Jan", `[A-Za-z]{3}`},
+	{"Mon", `[A-Za-z]{3}`},
+	{"MST", `[A-Za-z]+|[+-]\d+`},
+	{"-07", `[+-]\d{2}`},
+	{"_2", ` ?\d{1,2}`},
+	{"01", `\d{1,2}`},
+	{"02", `\d{1,2}`},
+	{"15", `\d{1,2}`},
+	{"04", `\d{1,2}`},
+	// time.Parse accepts fraction after seconds even if layout has none
+	{"05", `\d{1,2}(?:[.,]\d+)?`},
+	{"06", `\d{2}`},
+}
+
+// Finds where given field sits in value formatted with layout,
+// returns its text and offset, or false if value does not follow layout
+func findLayoutField(layout string, value string, field string) (string, int, bool) {
+	pattern := strings.Builder{}
+	pattern.WriteString("^")
+	groups := make([]string, 0)
+
+	for len(layout) > 0 {
+		matched := false
+		for _, e := range layoutElementPatterns {
+			if strings.HasPrefix(layout, e.element) {
+				pattern.WriteString("(" + e.pattern + ")")
+				groups = append(groups, layoutElementNames[e.element])
+				layout = layout[len(e.element):]
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			pattern.WriteString(regexp.QuoteMeta(layout[:1]))
+			layout = layout[1:]
+		}
+	}
+
+	pattern.WriteString("$")
+
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return "", 0, false
+	}
+
+	indexes := re.FindStringSubmatchIndex(value)
+	if indexes == nil {
+		return "", 0, false
+	}
+
+	for i, name := range groups {
+		if name == field {
+			start, end := indexes[2*i+2], indexes[2*i+3]
+			return strings.TrimSpace(value[start:end]), start, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// Turns error of time.Parse into candidate for the closest format
+func candidateFromTimeParseError(label string, layout string, value string, err *time.ParseError) parseCandidate {
+	position := len(value) - len(err.ValueElem)
+
+	// range errors come as ": month out of range"
+	if strings.HasSuffix(err.Message, " out of range") {
+		field := strings.TrimSuffix(strings.TrimPrefix(err.Message, ": "), " out of range")
+		reason := fmt.Sprintf("%s out of range", field)
+		position = -1
+
+		if text, offset, ok := findLayoutField(layout, value, field); ok {
+			reason = fmt.Sprintf("%s %s out of range", field, text)
+			position = offset
+		}
+
+		return parseCandidate{
+			detector: label,
+			reason:   reason,
+			position: position,
+			score:    len(value) + 1,
+		}
+	}
+
+	expected := fmt.Sprintf("%q", err.LayoutElem)
+	if name, ok := layoutElementNames[err.LayoutElem]; ok {
+		expected = name
+	}
+
+	reason := fmt.Sprintf("expected %s", expected)
+	if err.ValueElem == "" {
+		reason = fmt.Sprintf("input ends early, expected %s", expected)
+	} else if err.Message != "" {
+		reason = strings.TrimPrefix(err.Message, ": ")
+	}
+
+	return parseCandidate{
+		detector: label,
+		reason:   reason,
+		position: position,
+		score:    position,
+	}
+}
@@ -0,0 +1,148 @@
+package gui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sharki13/timestamp-converter/timezone"
+)
+
+func TestPraseStringToTime_Errors(t *testing.T) {
+	tests := []struct {
+		name              string
+		input             string
+		detectSerialDates bool
+		want              string
+	}{
+		{
+			name:  "month 13",
+			input: "2023-13-02T03:04:05Z",
+			want:  "month 13 out of range at position 6, closest format: " + FormatLabelMap[time.RFC3339],
+		},
+		{
+			name:  "30 February",
+			input: "2023-02-30T03:04:05Z",
+			want:  "day 30 out of range at position 9, closest format: " + FormatLabelMap[time.RFC3339],
+		},
+		{
+			name:  "missing zone",
+			input: "2023-01-02T03:04:05",
+			want:  "input ends early, expected zone offset at position 20, closest format: " + FormatLabelMap[time.RFC3339],
+		},
+		{
+			name:  "garbage after JD",
+			input: "JD abc",
+			want:  "invalid number after JD or MJD prefix, closest format: " + julianDateDetector,
+		},
+		{
+			name:  "garbage after MJD",
+			input: "MJD 12x",
+			want:  "invalid number after JD or MJD prefix, closest format: " + julianDateDetector,
+		},
+		{
+			name:  "out of range epoch",
+			input: "99999999999999999999",
+			want:  outOfRangeReason() + ", closest format: " + unixDetector,
+		},
+		{
+			name:  "out of range JD",
+			input: "JD 1e20",
+			want:  outOfRangeReason() + ", closest format: " + julianDateDetector,
+		},
+		{
+			name:              "serial date out of range",
+			input:             "-5.5",
+			detectSerialDates: true,
+			want:              "excel serial -5.5 out of range, closest format: " + serialDateDetector,
+		},
+		{
+			name:              "serial date which is not a number",
+			input:             "45000.5.1",
+			detectSerialDates: true,
+			want:              `expected "-" at position 5, closest format: ` + FormatLabelMap[time.RFC3339],
+		},
+		{
+			name:  "not a timestamp",
+			input: "hello",
+			want: "not a timestamp, tried: " + julianDateDetector + ", " +
+				FormatLabelMap[time.RFC1123Z] + ", " +
+				FormatLabelMap[time.RFC3339] + ", " +
+				FormatLabelMap[time.RFC822Z] + ", " +
+				FormatLabelMap[time.RubyDate] + ", " +
+				unixDetector,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := praseStringToTime(tt.input, parseOptions{
+				detectSerialDates: tt.detectSerialDates,
+				rowType:           timezone.LocalTimezoneType,
+			})
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected ParseError, got %v", err)
+			}
+
+			if err.Error() != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, err.Error())
+			}
+		})
+	}
+}
+
+func TestPraseStringToTime_WideRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{"far negative epoch", "-9000000000000000000", time.Unix(-9000000000000000000, 0)},
+		{"far epoch", "9000000000000000000", time.Unix(9000000000000000000, 0)},
+		{"far JD", "JD -100000000000000", time.Date(-273790705411, time.January, 17, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := praseStringToTime(tt.input, parseOptions{rowType: timezone.LocalTimezoneType})
+			if err != nil {
+				t.Fatalf("praseStringToTime() error = %v", err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("praseStringToTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPraseStringToTime_SerialDates(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		rowType timezone.TimezoneType
+		want    time.Time
+	}{
+		{"serial date in local row", "1", timezone.LocalTimezoneType, time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"Unix timestamp in Unix row", "0", timezone.UnixTimezoneType, time.Unix(0, 0)},
+		{"small Unix timestamp in Unix row", "45000", timezone.UnixTimezoneType, time.Unix(45000, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := praseStringToTime(tt.input, parseOptions{
+				detectSerialDates: true,
+				rowType:           tt.rowType,
+			})
+			if err != nil {
+				t.Fatalf("praseStringToTime() error = %v", err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("praseStringToTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package gui
+
+import (
+	"fmt"
+	"reflect"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Menu is filled with loaded profiles, see refreshProfileMenu
+func (t *TimestampConverter) makeProfileMenu() *fyne.Menu {
+	t.profileMenuLock.Lock()
+	t.profileMenu = fyne.NewMenu(ProfileLabel)
+	t.profileMenuLock.Unlock()
+
+	t.refreshProfileMenu()
+
+	return t.profileMenu
+}
+
+// Rebuilds profile menu, called whenever profiles change or another one is active
+func (t *TimestampConverter) refreshProfileMenu() {
+	t.profileMenuLock.Lock()
+	defer t.profileMenuLock.Unlock()
+
+	if t.profileMenu == nil || t.profiles == nil {
+		return
+	}
+
+	active := t.profiles.Active()
+	names := t.profiles.Names()
+
+	// nothing to rebuild, e.g. on the first call while the window is being built
+	shown := append(names, active)
+	if reflect.DeepEqual(shown, t.profileMenuShown) {
+		return
+	}
+	t.profileMenuShown = shown
+
+	items := make([]*fyne.MenuItem, 0)
+	deleteItems := make([]*fyne.MenuItem, 0)
+
+	for _, name := range names {
+		name := name
+
+		item := fyne.NewMenuItem(name, func() {
+			if err := t.profiles.Switch(name); err != nil {
+				dialog.ShowError(err, t.window)
+			}
+		})
+		item.Checked = name == active
+		items = append(items, item)
+
+		if name != active {
+			deleteItems = append(deleteItems, fyne.NewMenuItem(name, func() {
+				t.confirmDeleteProfile(name)
+			}))
+		}
+	}
+
+	deleteItem := fyne.NewMenuItem(DeleteProfileLabel, nil)
+	deleteItem.ChildMenu = fyne.NewMenu("", deleteItems...)
+	deleteItem.Disabled = len(deleteItems) == 0
+
+	items = append(items,
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(NewProfileLabel, func() {
+			t.askProfileName(NewProfileLabel, t.profiles.Create)
+		}),
+		fyne.NewMenuItem(CloneProfileLabel, func() {
+			t.askProfileName(CloneProfileLabel, func(name string) error {
+				return t.profiles.Clone(t.profiles.Active(), name)
+			})
+		}),
+		deleteItem,
+	)
+
+	t.profileMenu.Items = items
+
+	if mainMenu := t.window.MainMenu(); mainMenu != nil {
+		mainMenu.Refresh()
+	}
+}
+
+// Asks for profile name, then creates the profile with it and switches to it
+func (t *TimestampConverter) askProfileName(title string, create func(name string) error) {
+	nameEntry := widget.NewEntry()
+
+	dialog.ShowForm(title, CreateLabel, CancelLabel, []*widget.FormItem{
+		widget.NewFormItem(ProfileNameLabel, nameEntry),
+	}, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		if err := create(nameEntry.Text); err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+
+		if err := t.profiles.Switch(nameEntry.Text); err != nil {
+			dialog.ShowError(err, t.window)
+		}
+	}, t.window)
+}
+
+func (t *TimestampConverter) confirmDeleteProfile(name string) {
+	dialog.ShowConfirm(DeleteProfileLabel, fmt.Sprintf("Delete profile %s?", name), func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		if err := t.profiles.Delete(name); err != nil {
+			dialog.ShowError(err, t.window)
+		}
+	}, t.window)
+}
@@ -0,0 +1,163 @@
+package gui
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	prefSync "github.com/sharki13/timestamp-converter/preferences"
+)
+
+const settingsFileName = "timestamp-converter-settings.json"
+
+// Exports settings only, history, bookmarks and window state stay on this machine
+func (t *TimestampConverter) showExportSettingsDialog() {
+	t.showExportDialog(settingsFileName, func() ([]byte, error) {
+		return t.preferences.ExportKeys(settingsKeys)
+	})
+}
+
+// Saves what export returns to the file chosen by user
+func (t *TimestampConverter) showExportDialog(fileName string, export func() ([]byte, error)) {
+	save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+
+		// cancelled
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		data, err := export()
+		if err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+
+		if _, err := writer.Write(data); err != nil {
+			dialog.ShowError(err, t.window)
+		}
+	}, t.window)
+
+	save.SetFileName(fileName)
+	save.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	save.Show()
+}
+
+func (t *TimestampConverter) showImportSettingsDialog() {
+	t.showImportDialog(func(data []byte) error {
+		plan, err := t.preferences.PrepareImport(data)
+		if err != nil {
+			return err
+		}
+
+		t.showImportPreview(plan)
+		return nil
+	})
+}
+
+// Reads the file chosen by user and passes its content to read,
+// error it returns is shown
+func (t *TimestampConverter) showImportDialog(read func(data []byte) error) {
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+
+		// cancelled
+		if reader == nil {
+			return
+		}
+		defer reader.Close()
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			dialog.ShowError(err, t.window)
+			return
+		}
+
+		if err := read(data); err != nil {
+			dialog.ShowError(err, t.window)
+		}
+	}, t.window)
+
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	open.Show()
+}
+
+func (t *TimestampConverter) confirmResetSettings() {
+	dialog.ShowConfirm(ResetSettingsLabel, "Reset all settings and profiles to defaults? History and bookmarks are kept.", func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+
+		if err := t.preferences.ResetKeys(settingsKeys); err != nil {
+			dialog.ShowError(err, t.window)
+		}
+	}, t.window)
+}
+
+// Describes what import would do, so user can decide before anything changes
+func describeImportPlan(plan *prefSync.ImportPlan) string {
+	lines := make([]string, 0)
+
+	if len(plan.Changes) == 0 {
+		lines = append(lines, "No settings would change.")
+	} else {
+		lines = append(lines, "Settings to change:")
+		for _, change := range plan.Changes {
+			lines = append(lines, fmt.Sprintf("  %s: %s -> %s", change.Key, change.Current, change.New))
+		}
+	}
+
+	if len(plan.UnknownKeys) != 0 {
+		lines = append(lines, "", "Unknown settings, skipped:")
+		for _, key := range plan.UnknownKeys {
+			lines = append(lines, "  "+key)
+		}
+	}
+
+	if len(plan.Invalid) != 0 {
+		lines = append(lines, "", "Invalid settings, skipped:")
+		keys := make([]string, 0, len(plan.Invalid))
+		for key := range plan.Invalid {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("  %s: %s", key, plan.Invalid[key]))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (t *TimestampConverter) showImportPreview(plan *prefSync.ImportPlan) {
+	preview := widget.NewLabel(describeImportPlan(plan))
+	preview.Wrapping = fyne.TextWrapWord
+
+	d := dialog.NewCustomConfirm(ImportSettingsLabel, ApplyLabel, CancelLabel, container.NewVScroll(preview), func(apply bool) {
+		if !apply {
+			return
+		}
+
+		if err := plan.Apply(); err != nil {
+			dialog.ShowError(err, t.window)
+		}
+	}, t.window)
+
+	d.Resize(fyne.NewSize(500, 350))
+	d.Show()
+}
@@ -3,6 +3,11 @@ package gui
 const (
 	FileLabel               = "File"
 	QuitLabel               = "Quit"
+	ExportSettingsLabel     = "Export settings..."
+	ImportSettingsLabel     = "Import settings..."
+	ResetSettingsLabel      = "Reset settings"
+	ApplyLabel              = "Apply"
+	CancelLabel             = "Cancel"
 	GitHubPageLabel         = "GitHub page"
 	ProjectPageURL          = "https://github.com/sharki13/timestamp-converter"
 	HelpLabel               = "Help"
@@ -10,6 +15,36 @@ const (
 	LightLabel              = "Light"
 	DarkLabel               = "Dark"
 	ThemeLabel              = "Theme"
+	ProfileLabel            = "Profile"
+	NewProfileLabel         = "New profile..."
+	CloneProfileLabel       = "Clone current profile..."
+	DeleteProfileLabel      = "Delete profile"
+	CreateLabel             = "Create"
+	ProfileNameLabel        = "Name"
 	FormatLabel             = "Format"
+	ToolsLabel              = "Tools"
+	DurationLabel           = "Duration between times"
+	LeapSecondsLabel        = "Leap seconds"
+	FromLabel               = "From"
+	ToLabel                 = "To"
+	CloseLabel              = "Close"
+	UndoLabel               = "Undo"
+	RedoLabel               = "Redo"
+	EditLabel               = "Edit"
+	HistoryLabel            = "History"
+	KeepHistoryLabel        = "Keep history after restart"
+	BookmarksLabel          = "Bookmarks"
+	AddBookmarkLabel        = "Bookmark current timestamp"
+	DeleteBookmarkLabel     = "Delete bookmark"
+	BookmarkTimeLabel       = "Timestamp"
+	NotesLabel              = "Notes"
+	BookmarkNameLabel       = "Name"
+	SearchLabel             = "Search"
+	WatchClipboardLabel     = "Watch clipboard"
+	NotifyClipboardLabel    = "Notify about clipboard timestamps"
+	DetectSerialDatesLabel  = "Detect spreadsheet serial dates"
+	ShowDebugLogLabel       = "Show debug log"
+	DebugLogLabel           = "Debug log"
+	CopyLabel               = "Copy"
 	TimestampConverterLabel = "Timestamp Converter"
 )
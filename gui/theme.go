@@ -130,3 +130,28 @@ func (t *myTheme) Icon(i fyne.ThemeIconName) fyne.Resource {
 func (t *myTheme) Size(s fyne.ThemeSizeName) float32 {
 	return theme.DefaultTheme().Size(s)
 }
+
+// Sets theme of given variant, unless it is set already, changing theme
+// redraws every window, so it is not repeated e.g. on restoring preferences
+func (t *TimestampConverter) applyTheme(variant string) {
+	if current, ok := t.app.Settings().Theme().(*myTheme); ok && current.variant == variant {
+		return
+	}
+
+	t.app.Settings().SetTheme(&myTheme{variant: variant})
+}
+
+// Returns variant of myTheme chosen by theme preference,
+// system one follows the variant of the app settings
+func themeVariantOf(settings fyne.Settings, preference string) string {
+	switch preference {
+	case LightTheme, DarkTheme:
+		return preference
+	}
+
+	if settings.ThemeVariant() == theme.VariantLight {
+		return LightTheme
+	}
+
+	return DarkTheme
+}
@@ -1,9 +1,13 @@
 package gui
 
 import (
+	"context"
+	"sync"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/theme"
+	"github.com/sharki13/timestamp-converter/logging"
 	prefSync "github.com/sharki13/timestamp-converter/preferences"
 	"github.com/sharki13/timestamp-converter/xbinding"
 
@@ -16,11 +20,44 @@ type TimestampConverter struct {
 	visibleTimezones      xbinding.IntArray
 	timestamp             xbinding.Time
 	format                binding.String
-	watchClipboard        bool
+	detectSerialDates     binding.Bool
+	watchClipboard        binding.Bool
+	notifyClipboard       binding.Bool
 	theme                 binding.String
 	window                fyne.Window
 	app                   fyne.App
 	preferences           *prefSync.PreferencesSynchronizer
+	profiles              *prefSync.Profiles
+	profileMenu           *fyne.Menu
+	// menu is rebuilt by the listener of profiles and when it is made
+	profileMenuLock sync.Mutex
+	// names and active profile the menu shows, last one is the active
+	profileMenuShown []string
+	// rows of all timezones by id, shown or not
+	rows       map[int]timestampItemsSet
+	rowLabels  *fyne.Container
+	rowEntries *fyne.Container
+	// window state, tracked once it is restored
+	windowWidth      binding.Float
+	windowHeight     binding.Float
+	scrollOffset     binding.Float
+	trackWindowState bool
+	// started in setupAndLoadPreferences, stopped with the app
+	clipboardWatcher     *clipboardWatcher
+	stopClipboardWatcher context.CancelFunc
+	toast                *clipboardToast
+	// timestamps set before, for undo and redo
+	history      *history
+	savedHistory xbinding.List[historyEntry]
+	keepHistory  binding.Bool
+	// history is read and saved as a whole, so saves do not interleave
+	historySaveLock sync.Mutex
+	bookmarks       xbinding.List[bookmark]
+	showBookmarks   binding.Bool
+	// errors of listeners, shown at the bottom of the window
+	errors *errorSink
+	// recent log lines, nil when logging is not set up
+	debugLog *logging.Recent
 }
 
 func NewTimestampConverter(app fyne.App) *TimestampConverter {
@@ -36,12 +73,23 @@ func NewTimestampConverter(app fyne.App) *TimestampConverter {
 	return &ret
 }
 
+// Lines shown in Help -> Show debug log, should be called before ShowAndRun
+func (t *TimestampConverter) SetDebugLog(recent *logging.Recent) {
+	t.debugLog = recent
+}
+
 // Should be called near the end of the function
 // becasue it will block until the window is closed
 func (t *TimestampConverter) ShowAndRun() {
+	t.build()
+	t.window.ShowAndRun()
+}
+
+// Loads preferences and makes menu and content of the window with them,
+// so listeners called later do not change widgets while they are laid out
+func (t *TimestampConverter) build() {
+	t.setupAndLoadPreferences()
 	t.window.SetMainMenu(t.makeMenu())
 	t.window.SetContent(t.makeContent())
-	t.setupAndLoadPreferences()
-	t.window.Resize(fyne.NewSize(600, 400))
-	t.window.ShowAndRun()
+	t.restoreWindowState()
 }
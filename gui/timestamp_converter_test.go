@@ -0,0 +1,703 @@
+package gui
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	xwidget "fyne.io/x/fyne/widget"
+	prefSync "github.com/sharki13/timestamp-converter/preferences"
+	"github.com/sharki13/timestamp-converter/timezone"
+)
+
+// Longest chain of listeners called one after another, e.g. timestamp,
+// time string of a row and its entry, with room for a few more
+const listenerChainLength = 5
+
+// Tests queue hundreds of listeners at once, they are slow with race detector
+const listenerTimeout = 5 * time.Second
+
+// Timestamp shown by converters started in tests
+var testTimestamp = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestMain(m *testing.M) {
+	// local row shows the same time on every machine
+	time.Local = time.UTC
+
+	os.Exit(m.Run())
+}
+
+// Listeners of bindings are called asynchronously, so results are waited for,
+// condition is checked after listeners queued so far were called
+func eventually(t *testing.T, condition func() bool, message string) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !waitForListeners(t) || !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal(message)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Fyne calls listeners one by one in the order they were queued, so once
+// a listener added now is called, the ones queued before it were called too
+// and widgets they changed can be read without racing with them
+// Listeners queue further ones, e.g. entries follow their time string,
+// so it is repeated for as many steps as the longest such chain
+func waitForListeners(t *testing.T) bool {
+	t.Helper()
+
+	for i := 0; i < listenerChainLength; i++ {
+		called := make(chan struct{}, 1)
+		binding.NewBool().AddListener(binding.NewDataListener(func() { called <- struct{}{} }))
+
+		select {
+		case <-called:
+		case <-time.After(listenerTimeout):
+			t.Fatal("listeners were not called")
+			return false
+		}
+	}
+
+	return true
+}
+
+// Listeners are called one by one, so one which waits blocks the others,
+// returns function which lets them be called again
+func pauseListeners(t *testing.T) func() {
+	t.Helper()
+
+	paused := make(chan struct{})
+	released := make(chan struct{})
+	binding.NewBool().AddListener(binding.NewDataListener(func() {
+		close(paused)
+		<-released
+	}))
+
+	select {
+	case <-paused:
+	case <-time.After(listenerTimeout):
+		t.Fatal("listeners were not called")
+	}
+
+	return func() { close(released) }
+}
+
+// Runs action the way listeners are run, one by one with them, entries bound
+// to data write it while their listeners may already read it otherwise
+func asListener(t *testing.T, action func()) {
+	t.Helper()
+
+	done := make(chan struct{})
+	binding.NewBool().AddListener(binding.NewDataListener(func() {
+		action()
+		close(done)
+	}))
+
+	select {
+	case <-done:
+	case <-time.After(listenerTimeout):
+		t.Fatal("listeners were not called")
+	}
+}
+
+// Applies theme chosen in preferences and waits until it is applied
+func applyStoredTheme(t *testing.T, app fyne.App) {
+	t.Helper()
+
+	variant := themeVariantOf(app.Settings(), app.Preferences().String("theme"))
+	test.ApplyTheme(t, &myTheme{variant: variant})
+}
+
+// Builds converter the way ShowAndRun does and shows testTimestamp,
+// test fails if any error is reported while it runs
+func startConverter(t *testing.T, app fyne.App) *TimestampConverter {
+	t.Helper()
+
+	// theme is applied in the background and clears font cache, which building
+	// the window uses, so the one from preferences is applied beforehand
+	applyStoredTheme(t, app)
+
+	converter := NewTimestampConverter(app)
+	// entries are laid out while the window is built, bound ones read the error
+	// their listener sets, so listeners wait until it is built
+	release := pauseListeners(t)
+	converter.build()
+	release()
+
+	// entries write text they are set to by their listeners back to the binding,
+	// text of the time shown while building would revert the one set now
+	waitForListeners(t)
+	converter.setTimestamp(testTimestamp, historyNow)
+
+	t.Cleanup(func() {
+		converter.stop()
+
+		// theme set by the test is applied in the background, it would
+		// clear font cache while the next test renders, the one applied
+		// now is applied after it
+		waitForListeners(t)
+		applyStoredTheme(t, app)
+
+		for _, err := range converter.errors.reported() {
+			t.Errorf("unexpected error reported: %v", err)
+		}
+	})
+
+	// format may be restored from preferences
+	eventually(t, func() bool {
+		format, _ := converter.format.Get()
+		return rowEntry(t, converter, timezone.Local).Text == testTimestamp.Format(format)
+	}, "local row should show the timestamp")
+
+	// rows are still laid out by listeners, tests interacting with
+	// the window would race with them
+	waitForListeners(t)
+
+	return converter
+}
+
+func rowEntry(t *testing.T, converter *TimestampConverter, id int) *widget.Entry {
+	t.Helper()
+
+	for _, o := range converter.rows[id].entryCopyBtnContainer.Objects {
+		if entry, ok := o.(*widget.Entry); ok {
+			return entry
+		}
+	}
+
+	t.Fatalf("row %d has no entry", id)
+	return nil
+}
+
+func rowVisible(converter *TimestampConverter, id int) bool {
+	return converter.rows[id].entryCopyBtnContainer.Visible()
+}
+
+func rowDeleteButton(t *testing.T, converter *TimestampConverter, id int) *widget.Button {
+	t.Helper()
+
+	// delete is the first of row buttons
+	button, ok := converter.rows[id].deleteBtnLabelContainer.Objects[0].(*widget.Button)
+	if !ok {
+		t.Fatalf("row %d has no delete button", id)
+	}
+
+	return button
+}
+
+// Up and down buttons follow the delete button
+func rowMoveButton(t *testing.T, converter *TimestampConverter, id int, delta int) *widget.Button {
+	t.Helper()
+
+	index := 1
+	if delta > 0 {
+		index = 2
+	}
+
+	button, ok := converter.rows[id].deleteBtnLabelContainer.Objects[index].(*widget.Button)
+	if !ok {
+		t.Fatalf("row %d has no move button", id)
+	}
+
+	return button
+}
+
+// Ids of rows in the order they are laid out, hidden ones included
+func rowOrder(converter *TimestampConverter) []int {
+	order := []int{}
+	for _, o := range converter.rowLabels.Objects {
+		for id, row := range converter.rows {
+			if row.deleteBtnLabelContainer == o {
+				order = append(order, id)
+			}
+		}
+	}
+
+	return order
+}
+
+// Value in the app preferences, writes waiting for debounce are stored first
+func storedPreference(converter *TimestampConverter, key string) string {
+	converter.preferences.Flush()
+	return converter.app.Preferences().String(key)
+}
+
+// First object of type T in the window which matches
+func findObject[T fyne.CanvasObject](t *testing.T, converter *TimestampConverter, match func(T) bool) T {
+	t.Helper()
+
+	for _, o := range test.LaidOutObjects(converter.window.Content()) {
+		if object, ok := o.(T); ok && match(object) {
+			return object
+		}
+	}
+
+	var zero T
+	t.Fatalf("no %T found in the window", zero)
+	return zero
+}
+
+func findButton(t *testing.T, converter *TimestampConverter, text string, icon fyne.Resource) *widget.Button {
+	t.Helper()
+
+	return findObject(t, converter, func(b *widget.Button) bool {
+		return b.Text == text && b.Icon != nil && b.Icon.Name() == icon.Name()
+	})
+}
+
+func menuItem(t *testing.T, converter *TimestampConverter, menuLabel string, itemLabel string) *fyne.MenuItem {
+	t.Helper()
+
+	for _, menu := range converter.window.MainMenu().Items {
+		if menu.Label != menuLabel {
+			continue
+		}
+
+		for _, item := range menu.Items {
+			if item.Label == itemLabel {
+				return item
+			}
+		}
+	}
+
+	t.Fatalf("no %s item in %s menu", itemLabel, menuLabel)
+	return nil
+}
+
+func currentTimestamp(converter *TimestampConverter) time.Time {
+	timestamp, _ := converter.timestamp.Get()
+	return timestamp
+}
+
+func visibleIds(converter *TimestampConverter) []int {
+	ids, _ := converter.visibleTimezones.Get()
+	return ids
+}
+
+func TestConverter_TypeIntoRow(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+	converter.visibleTimezones.Set([]int{timezone.Local, timezone.Unix})
+	local := rowEntry(t, converter, timezone.Local)
+
+	// rows are laid out again by a listener, typing would race with it
+	eventually(t, func() bool {
+		return rowVisible(converter, timezone.Unix)
+	}, "added row should be shown")
+
+	// no prefix of the text is a timestamp, so typing is not interrupted
+	// by the row being reformatted
+	asListener(t, func() {
+		local.SetText("")
+		test.Type(local, "Sat, 03 Feb 2024 04:05:06 +0000")
+	})
+
+	typed := time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC)
+	eventually(t, func() bool {
+		return currentTimestamp(converter).Equal(typed)
+	}, "typed timestamp should be set")
+
+	eventually(t, func() bool {
+		return local.Text == "2024-02-03T04:05:06Z" && rowEntry(t, converter, timezone.Unix).Text == "1706933106"
+	}, "rows should show typed timestamp in their format")
+
+	asListener(t, func() { test.Type(local, " is not a time") })
+	time.Sleep(10 * time.Millisecond)
+
+	if !currentTimestamp(converter).Equal(typed) {
+		t.Fatalf("invalid text should not change timestamp, got %v", currentTimestamp(converter))
+	}
+}
+
+func TestConverter_Paste(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+	paste := findButton(t, converter, "", theme.ContentPasteIcon())
+
+	converter.window.Clipboard().SetContent("1700000000")
+	test.Tap(paste)
+
+	eventually(t, func() bool {
+		return currentTimestamp(converter).Equal(time.Unix(1700000000, 0))
+	}, "pasted timestamp should be set")
+
+	converter.window.Clipboard().SetContent("not a time")
+	test.Tap(paste)
+
+	if converter.window.Canvas().Overlays().Top() == nil {
+		t.Fatalf("invalid clipboard content should be reported in a dialog")
+	}
+
+	if !currentTimestamp(converter).Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("invalid clipboard content should not change timestamp")
+	}
+}
+
+func TestConverter_TimeScaleRow(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+	converter.visibleTimezones.Set([]int{timezone.Local, timezone.TAI})
+
+	// TAI is 37 seconds ahead of UTC since 2017
+	eventually(t, func() bool {
+		return rowVisible(converter, timezone.TAI) &&
+			rowEntry(t, converter, timezone.TAI).Text == "2023-01-02T03:04:42 TAI"
+	}, "TAI row should show its scale instead of zone")
+
+	tai := rowEntry(t, converter, timezone.TAI)
+	asListener(t, func() { tai.SetText("2024-02-03T04:05:43 TAI") })
+
+	eventually(t, func() bool {
+		return currentTimestamp(converter).Equal(time.Date(2024, 2, 3, 4, 5, 6, 0, time.UTC))
+	}, "text of TAI row should be read in TAI")
+}
+
+func TestConverter_Now(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+
+	before := time.Now()
+	test.Tap(findButton(t, converter, "Now", theme.ViewRefreshIcon()))
+	after := time.Now()
+
+	eventually(t, func() bool {
+		timestamp := currentTimestamp(converter)
+		return !timestamp.Before(before) && !timestamp.After(after)
+	}, "Now should set current time")
+}
+
+func TestConverter_AddAndDeleteZone(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+	add := findObject(t, converter, func(*xwidget.CompletionEntry) bool { return true })
+
+	test.Type(add, "Unix")
+
+	if !reflect.DeepEqual(add.Options, []string{"Unix"}) {
+		t.Fatalf("matching zones should be offered, got %v", add.Options)
+	}
+
+	// submitting clears the entry, which walks rows the listener hides and shows
+	asListener(t, func() { add.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn}) })
+
+	eventually(t, func() bool {
+		return reflect.DeepEqual(visibleIds(converter), []int{timezone.Local, timezone.Unix}) &&
+			rowVisible(converter, timezone.Unix)
+	}, "added zone should be shown below the others")
+
+	if add.Text != "" {
+		t.Fatalf("add entry should be cleared, got %q", add.Text)
+	}
+
+	if got := rowEntry(t, converter, timezone.Unix).Text; got != "1672628645" {
+		t.Fatalf("added row should show the timestamp, got %q", got)
+	}
+
+	if options := converter.getOptions("Unix"); len(options) != 0 {
+		t.Fatalf("shown zone should not be offered again, got %v", options)
+	}
+
+	test.Tap(rowDeleteButton(t, converter, timezone.Unix))
+
+	eventually(t, func() bool {
+		return reflect.DeepEqual(visibleIds(converter), []int{timezone.Local}) &&
+			!rowVisible(converter, timezone.Unix)
+	}, "deleted zone should be hidden")
+
+	if !rowDeleteButton(t, converter, timezone.Local).Disabled() {
+		t.Fatalf("local zone should not be deletable")
+	}
+}
+
+func TestConverter_MoveRow(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+	converter.visibleTimezones.Set([]int{timezone.Local, timezone.Unix, timezone.UTC})
+
+	eventually(t, func() bool {
+		return rowVisible(converter, timezone.UTC) && storedPreference(converter, "visibleTimezones") == "[0,1,17]"
+	}, "added zones should be shown and stored")
+
+	// moving keeps the number of zones, only their order changes
+	test.Tap(rowMoveButton(t, converter, timezone.Unix, 1))
+
+	eventually(t, func() bool {
+		return reflect.DeepEqual(rowOrder(converter)[:3], []int{timezone.Local, timezone.UTC, timezone.Unix})
+	}, "moved row should be shown below the next one")
+
+	eventually(t, func() bool {
+		return storedPreference(converter, "visibleTimezones") == "[0,17,1]"
+	}, "order of rows should be stored")
+
+	test.Tap(rowMoveButton(t, converter, timezone.Local, -1))
+	time.Sleep(10 * time.Millisecond)
+
+	if ids := visibleIds(converter); !reflect.DeepEqual(ids, []int{timezone.Local, timezone.UTC, timezone.Unix}) {
+		t.Fatalf("first row should not move up, got %v", ids)
+	}
+}
+
+func TestConverter_HistoryLimit(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+	converter.keepHistory.Set(true)
+
+	// more than history keeps, set before listeners are called, entries
+	// would write back text of a timestamp set before the last one otherwise
+	release := pauseListeners(t)
+	for i := 1; i <= historyLimit+5; i++ {
+		converter.setTimestamp(time.Unix(int64(i)*1000, 0), historyPasted)
+	}
+	release()
+
+	entries := converter.history.list()
+	if len(entries) != historyLimit {
+		t.Fatalf("history should keep %d entries, got %d", historyLimit, len(entries))
+	}
+
+	last := time.Unix((historyLimit+5)*1000, 0)
+	if !entries[len(entries)-1].Time.Equal(last) || entries[len(entries)-1].Source != historyPasted {
+		t.Fatalf("last entry should be the last timestamp, got %v", entries[len(entries)-1])
+	}
+
+	eventually(t, func() bool {
+		stored := []historyEntry{}
+		json.Unmarshal([]byte(storedPreference(converter, "history")), &stored)
+		return len(stored) == historyLimit && stored[len(stored)-1].Time.Equal(last)
+	}, "full history should still be stored")
+}
+
+func TestConverter_ResetSettings(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+	converter.keepHistory.Set(true)
+	converter.showBookmarks.Set(true)
+	converter.format.Set(time.RFC822Z)
+	converter.setTimestamp(time.Unix(1000, 0), historyPasted)
+
+	eventually(t, func() bool {
+		return storedPreference(converter, "format") == time.RFC822Z &&
+			storedPreference(converter, "history") != "[]"
+	}, "settings and history should be stored")
+
+	if err := converter.preferences.ResetKeys(settingsKeys); err != nil {
+		t.Fatalf("settings should be reset, got %v", err)
+	}
+
+	eventually(t, func() bool {
+		return storedPreference(converter, "format") == time.RFC3339
+	}, "format should be reset")
+
+	keepHistory, _ := converter.keepHistory.Get()
+	showBookmarks, _ := converter.showBookmarks.Get()
+	if !keepHistory || !showBookmarks || len(converter.history.list()) == 0 {
+		t.Fatalf("history and bookmarks should be left alone")
+	}
+}
+
+func TestConverter_ExportSettings(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+
+	data, err := converter.preferences.ExportKeys(settingsKeys)
+	if err != nil {
+		t.Fatalf("settings should be exported, got %v", err)
+	}
+
+	file := struct {
+		Preferences map[string]json.RawMessage `json:"preferences"`
+	}{}
+	json.Unmarshal(data, &file)
+
+	for _, key := range []string{"history", "bookmarks", "windowWidth", "scrollOffset"} {
+		if _, ok := file.Preferences[key]; ok {
+			t.Errorf("%s should not be exported with settings", key)
+		}
+	}
+
+	if _, ok := file.Preferences["format"]; !ok {
+		t.Errorf("format should be exported")
+	}
+}
+
+func TestConverter_ImportBookmarks(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+
+	release := bookmark{Name: "Release", Time: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)}
+	converter.bookmarks.Set([]bookmark{release})
+
+	data, _ := json.Marshal(map[string]interface{}{
+		"version": prefSync.DefaultMigrations.Latest(),
+		"preferences": map[string]interface{}{
+			"bookmarks": []bookmark{release, {Name: "Incident", Time: testTimestamp}},
+			"format":    time.RubyDate,
+		},
+	})
+
+	added, err := converter.importBookmarks(data)
+	if err != nil {
+		t.Fatalf("bookmarks should be imported, got %v", err)
+	}
+
+	if added != 1 {
+		t.Errorf("only new bookmark should be added, got %d", added)
+	}
+
+	bookmarks, _ := converter.bookmarks.Get()
+	if names := bookmarkNames(bookmarks); !reflect.DeepEqual(names, []string{"Release", "Incident"}) {
+		t.Errorf("bookmarks should be merged, got %v", names)
+	}
+
+	if format, _ := converter.format.Get(); format != time.RFC3339 {
+		t.Errorf("other settings in the file should not be imported, got format %q", format)
+	}
+
+	if _, err := converter.importBookmarks([]byte(`{"version": 1, "preferences": {"format": "2006"}}`)); err == nil {
+		t.Errorf("file without bookmarks should return an error")
+	}
+}
+
+func TestConverter_FormatMenu(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+
+	rfc822 := menuItem(t, converter, FormatLabel, FormatLabelMap[time.RFC822Z])
+	rfc3339 := menuItem(t, converter, FormatLabel, FormatLabelMap[time.RFC3339])
+
+	if !rfc3339.Checked || rfc822.Checked {
+		t.Fatalf("default format should be checked")
+	}
+
+	rfc822.Action()
+
+	eventually(t, func() bool {
+		return rowEntry(t, converter, timezone.Local).Text == testTimestamp.Format(time.RFC822Z)
+	}, "rows should use selected format")
+
+	eventually(t, func() bool {
+		return rfc822.Checked && !rfc3339.Checked
+	}, "selected format should be checked")
+}
+
+func TestConverter_ThemeMenu(t *testing.T) {
+	converter := startConverter(t, test.NewApp())
+
+	variant := func() string {
+		if th, ok := converter.app.Settings().Theme().(*myTheme); ok {
+			return th.variant
+		}
+		return ""
+	}
+
+	for _, tt := range []struct {
+		label   string
+		variant string
+	}{
+		{label: DarkLabel, variant: DarkTheme},
+		{label: LightLabel, variant: LightTheme},
+	} {
+		item := menuItem(t, converter, ThemeLabel, tt.label)
+		item.Action()
+
+		eventually(t, func() bool {
+			return variant() == tt.variant && item.Checked
+		}, tt.label+" theme should be applied and checked")
+
+		if menuItem(t, converter, ThemeLabel, SystemLabel).Checked {
+			t.Fatalf("system theme should not be checked with %s theme", tt.label)
+		}
+	}
+}
+
+func TestConverter_RestoreOnRestart(t *testing.T) {
+	app := test.NewApp()
+	first := startConverter(t, app)
+
+	menuItem(t, first, FormatLabel, FormatLabelMap[time.RubyDate]).Action()
+	menuItem(t, first, ThemeLabel, DarkLabel).Action()
+	first.visibleTimezones.Set([]int{timezone.Local, timezone.Unix, timezone.UTC})
+
+	eventually(t, func() bool {
+		return storedPreference(first, "visibleTimezones") == "[0,1,17]"
+	}, "added rows should be stored")
+
+	// moving keeps the number of rows, so it is stored on its own
+	first.moveRow(timezone.UTC, -1)
+
+	// bindings are stored by their listeners, which are called asynchronously
+	eventually(t, func() bool {
+		return storedPreference(first, "visibleTimezones") == "[0,17,1]" &&
+			storedPreference(first, "format") == time.RubyDate &&
+			storedPreference(first, "theme") == DarkTheme &&
+			reflect.DeepEqual(app.Settings().Theme(), &myTheme{variant: DarkTheme})
+	}, "format, theme and moved rows should be stored, theme should be set")
+
+	// quitting stores writes waiting for debounce
+	first.stop()
+
+	second := startConverter(t, app)
+
+	if format, _ := second.format.Get(); format != time.RubyDate {
+		t.Fatalf("format should be restored, got %q", format)
+	}
+
+	if themeVariant, _ := second.theme.Get(); themeVariant != DarkTheme {
+		t.Fatalf("theme should be restored, got %q", themeVariant)
+	}
+
+	if ids := visibleIds(second); !reflect.DeepEqual(ids, []int{timezone.Local, timezone.UTC, timezone.Unix}) {
+		t.Fatalf("zones and their order should be restored, got %v", ids)
+	}
+
+	eventually(t, func() bool {
+		return rowVisible(second, timezone.UTC) && rowVisible(second, timezone.Unix) &&
+			rowEntry(t, second, timezone.Local).Text == testTimestamp.Format(time.RubyDate)
+	}, "restored rows should be shown in restored format")
+}
+
+func TestConverter_NewerPreferences(t *testing.T) {
+	app := test.NewApp()
+	app.Preferences().SetInt(prefSync.SchemaVersionKey, prefSync.DefaultMigrations.Latest()+1)
+	app.Preferences().SetString("format", time.RubyDate)
+	applyStoredTheme(t, app)
+
+	converter := NewTimestampConverter(app)
+	release := pauseListeners(t)
+	converter.build()
+	release()
+
+	if len(converter.errors.reported()) != 1 {
+		t.Fatalf("preferences which cannot be migrated should be reported, got %v", converter.errors.reported())
+	}
+
+	// settings of this run are kept in memory
+	converter.format.Set(time.RFC822Z)
+	waitForListeners(t)
+	converter.stop()
+	applyStoredTheme(t, app)
+
+	if format := app.Preferences().String("format"); format != time.RubyDate {
+		t.Fatalf("newer preferences should be left untouched, got %q", format)
+	}
+}
+
+func TestConverter_Golden(t *testing.T) {
+	for _, variant := range []string{LightTheme, DarkTheme} {
+		t.Run(variant, func(t *testing.T) {
+			app := test.NewApp()
+			app.Preferences().SetString("theme", variant)
+			app.Preferences().SetString("visibleTimezones", "[0,1,17]")
+
+			converter := startConverter(t, app)
+
+			eventually(t, func() bool {
+				return rowVisible(converter, timezone.UTC) && rowVisible(converter, timezone.Unix)
+			}, "restored rows should be shown")
+
+			converter.window.Resize(fyne.NewSize(defaultWindowWidth, defaultWindowHeight))
+
+			test.AssertRendersToImage(t, "main_window_"+variant+".png", converter.window.Canvas())
+		})
+	}
+}
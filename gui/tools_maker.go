@@ -0,0 +1,160 @@
+package gui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/sharki13/timestamp-converter/timezone"
+)
+
+const leapSecondLayout = "2006-01-02 15:04"
+
+func (t *TimestampConverter) makeToolsMenu() *fyne.Menu {
+	duration := fyne.NewMenuItem(DurationLabel, func() {
+		t.showDurationDialog()
+	})
+
+	leapSeconds := fyne.NewMenuItem(LeapSecondsLabel, func() {
+		t.showLeapSecondsDialog()
+	})
+
+	return fyne.NewMenu(ToolsLabel, duration, leapSeconds, fyne.NewMenuItemSeparator(), t.makeToggleMenuItem(NotifyClipboardLabel, t.notifyClipboard))
+}
+
+// Formats interval between two instants, time.Duration would saturate
+// after ~292 years so days are counted separately, seconds between edges
+// of supported range do not fit into int64, but they do into uint64
+func formatInterval(from time.Time, to time.Time) string {
+	sign := ""
+	if to.Before(from) {
+		from, to = to, from
+		sign = "-"
+	}
+
+	seconds := uint64(to.Unix() - from.Unix())
+	nanoseconds := to.Nanosecond() - from.Nanosecond()
+
+	// fraction of the later instant is smaller, one second is borrowed
+	if nanoseconds < 0 {
+		seconds--
+		nanoseconds += int(time.Second)
+	}
+
+	days := seconds / (24 * 60 * 60)
+	rest := time.Duration(seconds%(24*60*60))*time.Second + time.Duration(nanoseconds)
+
+	if days == 0 {
+		return sign + rest.String()
+	}
+
+	return fmt.Sprintf("%s%dd %s", sign, days, rest)
+}
+
+// Describes interval between two instants, with a warning
+// when leap seconds make real elapsed time longer than UTC difference
+func describeInterval(from time.Time, to time.Time) string {
+	description := formatInterval(from, to)
+
+	leaps := timezone.LeapSecondsBetween(from, to)
+	if len(leaps) == 0 {
+		return description
+	}
+
+	elapsed := to.Add(time.Duration(len(leaps)) * time.Second)
+	if to.Before(from) {
+		elapsed = to.Add(-time.Duration(len(leaps)) * time.Second)
+	}
+
+	return fmt.Sprintf("%s\nInterval crosses %d leap second(s), last at %s:60 UTC,\nelapsed time is %s",
+		description,
+		len(leaps),
+		leaps[len(leaps)-1].Inserted().Format(leapSecondLayout),
+		formatInterval(from, elapsed))
+}
+
+func (t *TimestampConverter) showDurationDialog() {
+	timestamp, err := t.timestamp.Get()
+	if err != nil {
+		t.reportError(err)
+		return
+	}
+
+	fromEntry := widget.NewEntry()
+	fromEntry.SetText(timestamp.UTC().Format(time.RFC3339))
+	toEntry := widget.NewEntry()
+	toEntry.SetText(time.Now().UTC().Format(time.RFC3339))
+
+	result := widget.NewLabel("")
+
+	update := func(string) {
+		from, err := praseStringToTime(fromEntry.Text, t.parseOptions(timezone.LocalTimezoneType))
+		if err != nil {
+			result.SetText(fmt.Sprintf("%s: %s", FromLabel, err))
+			return
+		}
+
+		to, err := praseStringToTime(toEntry.Text, t.parseOptions(timezone.LocalTimezoneType))
+		if err != nil {
+			result.SetText(fmt.Sprintf("%s: %s", ToLabel, err))
+			return
+		}
+
+		result.SetText(describeInterval(from, to))
+	}
+
+	fromEntry.OnChanged = update
+	toEntry.OnChanged = update
+	update("")
+
+	form := widget.NewForm(
+		widget.NewFormItem(FromLabel, fromEntry),
+		widget.NewFormItem(ToLabel, toEntry),
+	)
+
+	d := dialog.NewCustom(DurationLabel, CloseLabel, container.NewVBox(form, result), t.window)
+	d.Resize(fyne.NewSize(450, 250))
+	d.Show()
+}
+
+func (t *TimestampConverter) showLeapSecondsDialog() {
+	// first entry is where the table starts, not a leap second
+	leaps := timezone.LeapSeconds[1:]
+
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(leaps) + 1, 2
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("0000-00-00 00:00:60 UTC")
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				if id.Col == 0 {
+					label.SetText("Leap second")
+				} else {
+					label.SetText("TAI-UTC after")
+				}
+				return
+			}
+
+			label.TextStyle = fyne.TextStyle{}
+			leap := leaps[len(leaps)-id.Row]
+			if id.Col == 0 {
+				label.SetText(leap.Inserted().Format(leapSecondLayout) + ":60 UTC")
+			} else {
+				label.SetText(fmt.Sprintf("%d s", leap.TAIMinusUTC))
+			}
+		},
+	)
+
+	d := dialog.NewCustom(LeapSecondsLabel, CloseLabel, table, t.window)
+	d.Resize(fyne.NewSize(450, 400))
+	d.Show()
+}
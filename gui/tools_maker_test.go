@@ -0,0 +1,36 @@
+package gui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sharki13/timestamp-converter/timezone"
+)
+
+func TestFormatInterval(t *testing.T) {
+	at := func(seconds int64, nanoseconds int64) time.Time {
+		return time.Unix(seconds, nanoseconds).UTC()
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     string
+	}{
+		{"same instant", at(0, 0), at(0, 0), "0s"},
+		{"under a day", at(0, 0), at(90, 500000000), "1m30.5s"},
+		{"days", at(0, 0), at(2*24*60*60+3600, 0), "2d 1h0m0s"},
+		{"borrowed second", at(0, 700000000), at(24*60*60, 400000000), "23h59m59.7s"},
+		{"borrowed second over a day", at(0, 300000000), at(24*60*60+1, 0), "1d 700ms"},
+		{"negative", at(24*60*60, 400000000), at(0, 700000000), "-23h59m59.7s"},
+		{"whole range", timezone.MinTime, timezone.MaxTime, "213503981520527d 23h59m59.999999999s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatInterval(tt.from, tt.to); got != tt.want {
+				t.Errorf("formatInterval() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package gui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	prefSync "github.com/sharki13/timestamp-converter/preferences"
+	"github.com/sharki13/timestamp-converter/timezone"
+)
+
+// Size of the window on the first launch
+const (
+	defaultWindowWidth  = 600
+	defaultWindowHeight = 400
+)
+
+// Layout which fills whole space with its objects,
+// like max layout, and reports every size change
+type sizeWatcherLayout struct {
+	inner    fyne.Layout
+	onResize func(size fyne.Size)
+}
+
+func newSizeWatcherLayout(onResize func(size fyne.Size)) fyne.Layout {
+	return &sizeWatcherLayout{
+		inner:    layout.NewMaxLayout(),
+		onResize: onResize,
+	}
+}
+
+func (l *sizeWatcherLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	l.inner.Layout(objects, size)
+	l.onResize(size)
+}
+
+func (l *sizeWatcherLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	return l.inner.MinSize(objects)
+}
+
+func positiveSize(size float64) error {
+	if size <= 0 {
+		return fmt.Errorf("size has to be positive")
+	}
+
+	return nil
+}
+
+// Registers window size and scroll position preferences
+// Fyne does not allow to move the window, so its position is left to the OS
+func (t *TimestampConverter) setupWindowStatePreferences() {
+	err := prefSync.Add[float64](t.preferences, prefSync.Preference[float64]{
+		Key:      "windowWidth",
+		Value:    t.windowWidth,
+		Fallback: defaultWindowWidth,
+		Codec:    prefSync.FloatCodec{},
+		Validate: positiveSize,
+	})
+
+	if err != nil {
+		t.reportError(err)
+	}
+
+	err = prefSync.Add[float64](t.preferences, prefSync.Preference[float64]{
+		Key:      "windowHeight",
+		Value:    t.windowHeight,
+		Fallback: defaultWindowHeight,
+		Codec:    prefSync.FloatCodec{},
+		Validate: positiveSize,
+	})
+
+	if err != nil {
+		t.reportError(err)
+	}
+
+	err = prefSync.Add[float64](t.preferences, prefSync.Preference[float64]{
+		Key:      "scrollOffset",
+		Value:    t.scrollOffset,
+		Fallback: 0,
+		Codec:    prefSync.FloatCodec{},
+	})
+
+	if err != nil {
+		t.reportError(err)
+	}
+}
+
+// Resizes window to the stored size, from then on size and scroll position are tracked
+func (t *TimestampConverter) restoreWindowState() {
+	width, err := t.windowWidth.Get()
+	if err != nil {
+		t.reportError(err)
+		return
+	}
+
+	height, err := t.windowHeight.Get()
+	if err != nil {
+		t.reportError(err)
+		return
+	}
+
+	t.window.Resize(fyne.NewSize(float32(width), float32(height)))
+	t.trackWindowState = true
+}
+
+// Wraps content, so size of the window and scroll position are stored when they change
+func (t *TimestampConverter) watchWindowState(content fyne.CanvasObject, scroll *container.Scroll) fyne.CanvasObject {
+	scrollRestored := false
+
+	scroll.OnScrolled = func(offset fyne.Position) {
+		if t.trackWindowState && scrollRestored {
+			t.scrollOffset.Set(float64(offset.Y))
+		}
+	}
+
+	return container.New(newSizeWatcherLayout(func(fyne.Size) {
+		if !t.trackWindowState {
+			return
+		}
+
+		// content has its final size first time here, so offset is not cut off
+		if !scrollRestored {
+			scrollRestored = true
+
+			offset, err := t.scrollOffset.Get()
+			if err != nil {
+				t.reportError(err)
+				return
+			}
+
+			scroll.Offset.Y = float32(offset)
+			scroll.Refresh()
+		}
+
+		// window size includes main menu, the same as Resize expects
+		size := t.window.Canvas().Size()
+		t.windowWidth.Set(float64(size.Width))
+		t.windowHeight.Set(float64(size.Height))
+	}), content)
+}
+
+// Returns ids of visible rows in the order they are shown,
+// Local is always shown, at the top unless it was moved
+func orderedVisibleIds(ids []int) []int {
+	if contains(ids, timezone.Local) {
+		return ids
+	}
+
+	return append([]int{timezone.Local}, ids...)
+}
+
+// Returns ids with given id moved by delta places, ids stay as they are
+// if the id is not there or would move out of the list
+func moveId(ids []int, id int, delta int) []int {
+	from := -1
+	for i, v := range ids {
+		if v == id {
+			from = i
+			break
+		}
+	}
+
+	to := from + delta
+	if from < 0 || to < 0 || to >= len(ids) {
+		return ids
+	}
+
+	moved := append([]int{}, ids...)
+	moved[from], moved[to] = moved[to], moved[from]
+
+	return moved
+}
+
+// Moves row of given timezone up (negative delta) or down
+func (t *TimestampConverter) moveRow(id int, delta int) {
+	ids, err := t.visibleTimezones.Get()
+	if err != nil {
+		t.reportError(err)
+		return
+	}
+
+	t.visibleTimezones.Set(moveId(orderedVisibleIds(ids), id, delta))
+}
+
+// Puts rows in the order of visible timezones, hidden ones go after them,
+// rows are changed only if their order or visibility changes
+func (t *TimestampConverter) arrangeRows(visibleIds []int) {
+	if t.rowLabels == nil || t.rowEntries == nil {
+		return
+	}
+
+	order := orderedVisibleIds(visibleIds)
+	visibleCount := len(order)
+	for _, tz := range timezone.Timezones {
+		if !contains(order, tz.Id) {
+			order = append(order, tz.Id)
+		}
+	}
+
+	labels := make([]fyne.CanvasObject, 0, len(order))
+	entries := make([]fyne.CanvasObject, 0, len(order))
+	changed := false
+
+	for i, id := range order {
+		row, ok := t.rows[id]
+		if !ok {
+			continue
+		}
+
+		if hidden := i >= visibleCount; row.entryCopyBtnContainer.Hidden != hidden {
+			row.deleteBtnLabelContainer.Hidden = hidden
+			row.entryCopyBtnContainer.Hidden = hidden
+			changed = true
+		}
+
+		labels = append(labels, row.deleteBtnLabelContainer)
+		entries = append(entries, row.entryCopyBtnContainer)
+	}
+
+	if !changed && sameObjects(labels, t.rowLabels.Objects) {
+		return
+	}
+
+	t.rowLabels.Objects = labels
+	t.rowEntries.Objects = entries
+	t.rowLabels.Refresh()
+	t.rowEntries.Refresh()
+}
+
+// Reports whether both lists have the same objects in the same order
+func sameObjects(a, b []fyne.CanvasObject) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Buttons which move row of given timezone up and down
+func (t *TimestampConverter) makeMoveRowButtons(id int) []fyne.CanvasObject {
+	return []fyne.CanvasObject{
+		widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() { t.moveRow(id, -1) }),
+		widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() { t.moveRow(id, 1) }),
+	}
+}
@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environment variable with log level, used when the flag is not given
+const LevelEnv = "TIMESTAMP_CONVERTER_LOG_LEVEL"
+
+// Log file is rotated when it grows over that size, older files are kept as .1, .2 ...
+const (
+	DefaultMaxSize    = 1 << 20
+	DefaultMaxBackups = 3
+)
+
+// How many lines are kept for the debug log window
+const DefaultRecentLines = 1000
+
+// Parses level name like debug, info, warn or error, case is ignored,
+// offsets like debug+2 are accepted too
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q, expected debug, info, warn or error", s)
+	}
+
+	return level, nil
+}
+
+// Level from the flag, from the environment when flag is empty,
+// info when neither is set
+func LevelFrom(flagValue string, envValue string) (slog.Level, error) {
+	if flagValue != "" {
+		return ParseLevel(flagValue)
+	}
+
+	if envValue != "" {
+		return ParseLevel(envValue)
+	}
+
+	return slog.LevelInfo, nil
+}
+
+// Log file next to the preferences, fyne keeps them in
+// <user config directory>/fyne/<app id>
+func DefaultFilePath(appID string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "fyne", appID, "debug.log"), nil
+}
+
+type Options struct {
+	Level slog.Leveler
+	// console output, e.g. os.Stderr, nil disables it
+	Console io.Writer
+	// log file, rotated when it grows over MaxSize, empty disables it
+	FilePath   string
+	MaxSize    int64
+	MaxBackups int
+	// lines kept for the debug log window
+	RecentLines int
+}
+
+// Sets default slog logger, which the standard log package writes to as well,
+// file which cannot be opened is reported in the log and skipped
+// Returned function closes the log file
+func Setup(opts Options) (*Recent, func() error) {
+	recent := NewRecent(opts.RecentLines)
+	writers := []io.Writer{recent}
+	closeFile := func() error { return nil }
+
+	if opts.Console != nil {
+		writers = append(writers, opts.Console)
+	}
+
+	var fileErr error
+	if opts.FilePath != "" {
+		file, err := NewRotatingFile(opts.FilePath, opts.MaxSize, opts.MaxBackups)
+		if err != nil {
+			fileErr = err
+		} else {
+			writers = append(writers, file)
+			closeFile = file.Close
+		}
+	}
+
+	handler := slog.NewTextHandler(io.MultiWriter(writers...), &slog.HandlerOptions{Level: opts.Level})
+	slog.SetDefault(slog.New(handler))
+
+	if fileErr != nil {
+		slog.Warn("log file cannot be opened", "path", opts.FilePath, "err", fileErr)
+	}
+
+	return recent, closeFile
+}
@@ -0,0 +1,160 @@
+package logging
+
+import (
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    slog.Level
+		wantErr bool
+	}{
+		{input: "debug", want: slog.LevelDebug},
+		{input: "INFO", want: slog.LevelInfo},
+		{input: " warn ", want: slog.LevelWarn},
+		{input: "error", want: slog.LevelError},
+		{input: "debug+2", want: slog.LevelDebug + 2},
+		{input: "verbose", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLevel(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && got != tt.want {
+				t.Fatalf("ParseLevel(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevelFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    string
+		env     string
+		want    slog.Level
+		wantErr bool
+	}{
+		{name: "default", want: slog.LevelInfo},
+		{name: "env", env: "debug", want: slog.LevelDebug},
+		{name: "flag wins", flag: "error", env: "debug", want: slog.LevelError},
+		{name: "invalid env", env: "loud", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LevelFrom(tt.flag, tt.env)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LevelFrom(%q, %q) error = %v, wantErr %v", tt.flag, tt.env, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && got != tt.want {
+				t.Fatalf("LevelFrom(%q, %q) = %v, want %v", tt.flag, tt.env, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecent(t *testing.T) {
+	recent := NewRecent(2)
+
+	notified := 0
+	remove := recent.AddListener(func() { notified++ })
+
+	recent.Write([]byte("first\nsec"))
+	recent.Write([]byte("ond\nthird\n"))
+
+	if got := recent.String(); got != "second\nthird" {
+		t.Fatalf("only last complete lines should be kept, got %q", got)
+	}
+
+	if notified != 2 {
+		t.Fatalf("listener should be called after every write, got %d calls", notified)
+	}
+
+	remove()
+	recent.Write([]byte("fourth\n"))
+
+	if notified != 2 {
+		t.Fatalf("removed listener should not be called")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	previous := slog.Default()
+	defer slog.SetDefault(previous)
+
+	path := filepath.Join(t.TempDir(), "app", "debug.log")
+
+	recent, closeFile := Setup(Options{
+		Level:    slog.LevelWarn,
+		FilePath: path,
+	})
+
+	slog.Info("hidden")
+	slog.Warn("shown", "key", "value")
+	log.Printf("from log package")
+
+	if err := closeFile(); err != nil {
+		t.Fatalf("close should not return an error: %v", err)
+	}
+
+	lines := recent.Lines()
+	if len(lines) != 1 || !strings.Contains(lines[0], "msg=shown key=value") {
+		t.Fatalf("only lines at level or above should be kept, got %q", lines)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("log file should be created: %v", err)
+	}
+
+	if string(content) != lines[0]+"\n" {
+		t.Fatalf("log file should have the same lines, got %q", content)
+	}
+}
+
+func TestSetup_FileError(t *testing.T) {
+	previous := slog.Default()
+	defer slog.SetDefault(previous)
+
+	// parent of the log file is a file, so it cannot be created
+	parent := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(parent, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	recent, closeFile := Setup(Options{
+		Level:    slog.LevelInfo,
+		FilePath: filepath.Join(parent, "debug.log"),
+	})
+	defer closeFile()
+
+	if !strings.Contains(recent.String(), "log file cannot be opened") {
+		t.Fatalf("file error should be logged, got %q", recent.String())
+	}
+}
+
+func TestDefaultFilePath(t *testing.T) {
+	path, err := DefaultFilePath("com.example.app")
+	if err != nil {
+		t.Skipf("no user config directory: %v", err)
+	}
+
+	// fyne keeps preferences in fyne/<app id> of user config directory
+	want := filepath.Join("fyne", "com.example.app", "debug.log")
+	if !strings.HasSuffix(path, want) {
+		t.Fatalf("expected path ending with %s, got %s", want, path)
+	}
+}
@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"strings"
+	"sync"
+)
+
+// Keeps last lines of the log in memory, for the debug log window
+type Recent struct {
+	limit int
+
+	lock      sync.Mutex
+	lines     []string
+	partial   string
+	listeners map[int]func()
+	nextID    int
+}
+
+// Zero or less limit means the default one
+func NewRecent(limit int) *Recent {
+	if limit <= 0 {
+		limit = DefaultRecentLines
+	}
+
+	return &Recent{
+		limit:     limit,
+		listeners: make(map[int]func()),
+	}
+}
+
+// Adds complete lines, text after the last new line waits for the next write
+func (r *Recent) Write(p []byte) (int, error) {
+	r.lock.Lock()
+
+	text := r.partial + string(p)
+	lines := strings.Split(text, "\n")
+	r.partial = lines[len(lines)-1]
+
+	r.lines = append(r.lines, lines[:len(lines)-1]...)
+	if len(r.lines) > r.limit {
+		r.lines = append([]string{}, r.lines[len(r.lines)-r.limit:]...)
+	}
+
+	listeners := make([]func(), 0, len(r.listeners))
+	for _, l := range r.listeners {
+		listeners = append(listeners, l)
+	}
+
+	r.lock.Unlock()
+
+	// called without lock, so listeners can read lines
+	for _, l := range listeners {
+		l()
+	}
+
+	return len(p), nil
+}
+
+// Copy of the kept lines, oldest first
+func (r *Recent) Lines() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]string{}, r.lines...)
+}
+
+func (r *Recent) String() string {
+	return strings.Join(r.Lines(), "\n")
+}
+
+// Calls listener after every write, returned function removes it
+func (r *Recent) AddListener(listener func()) func() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id := r.nextID
+	r.nextID++
+	r.listeners[id] = listener
+
+	return func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		delete(r.listeners, id)
+	}
+}
@@ -0,0 +1,105 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cannot read %s: %v", path, err)
+	}
+
+	return string(content)
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.log")
+
+	file, err := NewRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("NewRotatingFile should not return an error: %v", err)
+	}
+
+	for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeee\n", "ffff\n", "gggg\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatalf("Write should not return an error: %v", err)
+		}
+	}
+
+	if err := file.Close(); err != nil {
+		t.Fatalf("Close should not return an error: %v", err)
+	}
+
+	expected := map[string]string{
+		path:        "gggg\n",
+		path + ".1": "eeee\nffff\n",
+		path + ".2": "cccc\ndddd\n",
+	}
+
+	for p, want := range expected {
+		if got := readFile(t, p); got != want {
+			t.Fatalf("%s = %q, want %q", filepath.Base(p), got, want)
+		}
+	}
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("files over max backups should be removed")
+	}
+}
+
+func TestRotatingFile_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.log")
+
+	for _, line := range []string{"first\n", "second\n"} {
+		file, err := NewRotatingFile(path, 100, 1)
+		if err != nil {
+			t.Fatalf("NewRotatingFile should not return an error: %v", err)
+		}
+
+		file.Write([]byte(line))
+		file.Close()
+	}
+
+	if got := readFile(t, path); got != "first\nsecond\n" {
+		t.Fatalf("reopened file should be appended to, got %q", got)
+	}
+}
+
+func TestRotatingFile_NoBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.log")
+
+	file, err := NewRotatingFile(path, 6, 0)
+	if err != nil {
+		t.Fatalf("NewRotatingFile should not return an error: %v", err)
+	}
+	defer file.Close()
+
+	file.Write([]byte("aaaa\n"))
+	file.Write([]byte("bbbb\n"))
+
+	if got := readFile(t, path); got != "bbbb\n" {
+		t.Fatalf("file should start over, got %q", got)
+	}
+
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Fatalf("no backup should be kept")
+	}
+}
+
+func TestRotatingFile_Closed(t *testing.T) {
+	file, err := NewRotatingFile(filepath.Join(t.TempDir(), "debug.log"), 0, 1)
+	if err != nil {
+		t.Fatalf("NewRotatingFile should not return an error: %v", err)
+	}
+
+	file.Close()
+
+	if _, err := file.Write([]byte("late\n")); err == nil {
+		t.Fatalf("Write after Close should return an error")
+	}
+}
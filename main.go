@@ -1,13 +1,43 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+
 	"fyne.io/fyne/v2/app"
 	"github.com/sharki13/timestamp-converter/gui"
+	"github.com/sharki13/timestamp-converter/logging"
 )
 
+const appID = "github.com.sharki13.timestamp-converter"
+
 func main() {
-	app := app.NewWithID("github.com.sharki13.timestamp-converter")
+	logLevel := flag.String("log-level", "", "log level: debug, info, warn or error, "+logging.LevelEnv+" is used when not set")
+	flag.Parse()
+
+	level, err := logging.LevelFrom(*logLevel, os.Getenv(logging.LevelEnv))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// log file is optional, app works without config directory
+	logPath, _ := logging.DefaultFilePath(appID)
+
+	recent, closeLog := logging.Setup(logging.Options{
+		Level:       level,
+		Console:     os.Stderr,
+		FilePath:    logPath,
+		MaxSize:     logging.DefaultMaxSize,
+		MaxBackups:  logging.DefaultMaxBackups,
+		RecentLines: logging.DefaultRecentLines,
+	})
+	defer closeLog()
+
+	app := app.NewWithID(appID)
 	tc := gui.NewTimestampConverter(app)
+	tc.SetDebugLog(recent)
 
 	tc.ShowAndRun()
 }
@@ -0,0 +1,330 @@
+package preferences
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Codec converts preference value to the form it is stored in and back
+// Load returns fallback if key was never stored
+type Codec[T any] interface {
+	Load(prefs Storage, key string, fallback T) (T, error)
+	Store(prefs Storage, key string, value T) error
+}
+
+// Optional for codecs of types which plain JSON does not fit,
+// used for export and profiles instead of json.Marshal
+type JSONCodecOverride[T any] interface {
+	MarshalJSONValue(value T) (json.RawMessage, error)
+	UnmarshalJSONValue(raw json.RawMessage) (T, error)
+}
+
+// Stores string as is
+type StringCodec struct{}
+
+func (StringCodec) Load(prefs Storage, key string, fallback string) (string, error) {
+	return prefs.StringWithFallback(key, fallback), nil
+}
+
+func (StringCodec) Store(prefs Storage, key string, value string) error {
+	prefs.SetString(key, value)
+	return nil
+}
+
+// Stores int as is
+type IntCodec struct{}
+
+func (IntCodec) Load(prefs Storage, key string, fallback int) (int, error) {
+	return prefs.IntWithFallback(key, fallback), nil
+}
+
+func (IntCodec) Store(prefs Storage, key string, value int) error {
+	prefs.SetInt(key, value)
+	return nil
+}
+
+// Stores bool as is
+type BoolCodec struct{}
+
+func (BoolCodec) Load(prefs Storage, key string, fallback bool) (bool, error) {
+	return prefs.BoolWithFallback(key, fallback), nil
+}
+
+func (BoolCodec) Store(prefs Storage, key string, value bool) error {
+	prefs.SetBool(key, value)
+	return nil
+}
+
+// Stores float64 as is
+type FloatCodec struct{}
+
+func (FloatCodec) Load(prefs Storage, key string, fallback float64) (float64, error) {
+	return prefs.FloatWithFallback(key, fallback), nil
+}
+
+func (FloatCodec) Store(prefs Storage, key string, value float64) error {
+	prefs.SetFloat(key, value)
+	return nil
+}
+
+// Stores time.Duration as string, e.g. "1h30m0s"
+type DurationCodec struct{}
+
+func (DurationCodec) Load(prefs Storage, key string, fallback time.Duration) (time.Duration, error) {
+	serialized := prefs.String(key)
+	if serialized == "" {
+		return fallback, nil
+	}
+
+	return time.ParseDuration(serialized)
+}
+
+func (DurationCodec) Store(prefs Storage, key string, value time.Duration) error {
+	prefs.SetString(key, value.String())
+	return nil
+}
+
+// Stores time.Duration as ISO 8601 duration, e.g. "PT1H30M"
+// Days and weeks are read as 24 hours and 7 days, years and months
+// have no fixed length, so they are rejected
+type ISODurationCodec struct{}
+
+func (ISODurationCodec) Load(prefs Storage, key string, fallback time.Duration) (time.Duration, error) {
+	serialized := prefs.String(key)
+	if serialized == "" {
+		return fallback, nil
+	}
+
+	return ParseISODuration(serialized)
+}
+
+func (ISODurationCodec) Store(prefs Storage, key string, value time.Duration) error {
+	prefs.SetString(key, FormatISODuration(value))
+	return nil
+}
+
+var isoDurationRegex = regexp.MustCompile(`^(-)?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)(?:[.,](\d{1,9}))?S)?)?$`)
+
+// Parses ISO 8601 duration, see ISODurationCodec
+func ParseISODuration(s string) (time.Duration, error) {
+	match := isoDurationRegex.FindStringSubmatch(s)
+	// "P" and "PT" alone have no value
+	if match == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("%q is not ISO 8601 duration", s)
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+	// magnitude is summed unsigned, negative durations reach one further
+	negative := match[1] == "-"
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+
+	outOfRange := fmt.Errorf("%q is out of range of time.Duration", s)
+
+	var total uint64
+	for i, unit := range units {
+		if match[i+2] == "" {
+			continue
+		}
+
+		n, err := strconv.ParseUint(match[i+2], 10, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, outOfRange
+		} else if err != nil {
+			return 0, err
+		}
+
+		if n > (limit-total)/uint64(unit) {
+			return 0, outOfRange
+		}
+
+		total += n * uint64(unit)
+	}
+
+	if fraction := match[7]; fraction != "" {
+		// padded to nanoseconds
+		nanos, err := strconv.ParseUint(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+
+		if nanos > limit-total {
+			return 0, outOfRange
+		}
+
+		total += nanos
+	}
+
+	d := time.Duration(total)
+	if negative {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// Formats duration as ISO 8601 in hours, minutes and seconds, e.g. "PT36H0.5S"
+func FormatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	// -d overflows for the smallest duration, its magnitude fits into uint64
+	var b strings.Builder
+	magnitude := uint64(d)
+	if d < 0 {
+		b.WriteString("-")
+		magnitude = -magnitude
+	}
+
+	b.WriteString("PT")
+
+	if hours := magnitude / uint64(time.Hour); hours != 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+
+	if minutes := magnitude % uint64(time.Hour) / uint64(time.Minute); minutes != 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+
+	seconds := magnitude % uint64(time.Minute) / uint64(time.Second)
+	nanos := magnitude % uint64(time.Second)
+
+	if nanos != 0 {
+		fraction := strings.TrimRight(fmt.Sprintf("%09d", nanos), "0")
+		fmt.Fprintf(&b, "%d.%sS", seconds, fraction)
+	} else if seconds != 0 {
+		fmt.Fprintf(&b, "%dS", seconds)
+	}
+
+	return b.String()
+}
+
+// Exported as ISO 8601 string too, not as nanoseconds
+func (ISODurationCodec) MarshalJSONValue(value time.Duration) (json.RawMessage, error) {
+	return json.Marshal(FormatISODuration(value))
+}
+
+func (ISODurationCodec) UnmarshalJSONValue(raw json.RawMessage) (time.Duration, error) {
+	var serialized string
+	if err := json.Unmarshal(raw, &serialized); err != nil {
+		return 0, err
+	}
+
+	return ParseISODuration(serialized)
+}
+
+// Stores *time.Location as IANA name, e.g. "Europe/Warsaw"
+type LocationCodec struct{}
+
+func (LocationCodec) Load(prefs Storage, key string, fallback *time.Location) (*time.Location, error) {
+	serialized := prefs.String(key)
+	if serialized == "" {
+		return fallback, nil
+	}
+
+	return time.LoadLocation(serialized)
+}
+
+func (LocationCodec) Store(prefs Storage, key string, value *time.Location) error {
+	if value == nil {
+		return fmt.Errorf("location cannot be nil")
+	}
+
+	prefs.SetString(key, value.String())
+	return nil
+}
+
+// time.Location has no JSON form, so it is exported as IANA name
+func (LocationCodec) MarshalJSONValue(value *time.Location) (json.RawMessage, error) {
+	if value == nil {
+		return nil, fmt.Errorf("location cannot be nil")
+	}
+
+	return json.Marshal(value.String())
+}
+
+func (LocationCodec) UnmarshalJSONValue(raw json.RawMessage) (*time.Location, error) {
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		return nil, err
+	}
+
+	return time.LoadLocation(name)
+}
+
+// Stores time.Time as RFC3339 string with nanoseconds
+type TimeCodec struct{}
+
+func (TimeCodec) Load(prefs Storage, key string, fallback time.Time) (time.Time, error) {
+	serialized := prefs.String(key)
+	if serialized == "" {
+		return fallback, nil
+	}
+
+	return time.Parse(time.RFC3339Nano, serialized)
+}
+
+func (TimeCodec) Store(prefs Storage, key string, value time.Time) error {
+	prefs.SetString(key, value.Format(time.RFC3339Nano))
+	return nil
+}
+
+// Stores any value as JSON string, fits slices, maps and structs
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Load(prefs Storage, key string, fallback T) (T, error) {
+	serialized := prefs.String(key)
+	if serialized == "" {
+		return fallback, nil
+	}
+
+	var value T
+	if err := json.Unmarshal([]byte(serialized), &value); err != nil {
+		return fallback, err
+	}
+
+	return value, nil
+}
+
+func (JSONCodec[T]) Store(prefs Storage, key string, value T) error {
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	prefs.SetString(key, string(serialized))
+	return nil
+}
+
+// Stores list of strings as JSON array
+type StringListCodec = JSONCodec[[]string]
+
+// Stores map with string keys as JSON object
+type MapCodec[V any] struct {
+	JSONCodec[map[string]V]
+}
+
+// Stores []int as JSON array, empty array is treated as not stored
+// to keep behaviour of preferences saved by earlier versions
+type intArrayCodec struct {
+	JSONCodec[[]int]
+}
+
+func (c intArrayCodec) Load(prefs Storage, key string, fallback []int) ([]int, error) {
+	serialized := prefs.StringWithFallback(key, "[]")
+	if serialized == "[]" {
+		return fallback, nil
+	}
+
+	return c.JSONCodec.Load(prefs, key, fallback)
+}
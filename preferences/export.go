@@ -0,0 +1,266 @@
+package preferences
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Layout of exported settings file
+type exportFile struct {
+	Version     int                        `json:"version"`
+	Preferences map[string]json.RawMessage `json:"preferences"`
+}
+
+// Serializes all registered preferences to versioned JSON
+func (p *PreferencesSynchronizer) Export() ([]byte, error) {
+	return p.ExportKeys(p.Keys())
+}
+
+// Serializes given preferences only, e.g. bookmarks to share,
+// the file is imported the same way as full one
+func (p *PreferencesSynchronizer) ExportKeys(keys []string) ([]byte, error) {
+	file := exportFile{
+		Version:     p.version,
+		Preferences: make(map[string]json.RawMessage),
+	}
+
+	for _, key := range keys {
+		pref, ok := p.registry[key]
+		if !ok {
+			return nil, fmt.Errorf("key %s is not registered", key)
+		}
+
+		raw, err := pref.exportJSON()
+		if err != nil {
+			return nil, fmt.Errorf("cannot export %s: %w", key, err)
+		}
+
+		file.Preferences[key] = raw
+	}
+
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// Value from imported file which differs from the current one
+type ImportChange struct {
+	Key     string
+	Current string
+	New     string
+}
+
+// Result of validating settings file, nothing is applied until Apply is called
+type ImportPlan struct {
+	// values which differ from the current ones
+	Changes []ImportChange
+	// keys in file which no registered preference has
+	UnknownKeys []string
+	// keys which value cannot be read as the preference type, with reason
+	Invalid map[string]string
+	apply   []func() error
+}
+
+// Applies all valid changes of the plan
+func (plan *ImportPlan) Apply() error {
+	for _, apply := range plan.apply {
+		if err := apply(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reads settings file, file of older schema version is migrated,
+// newer one is rejected
+func (p *PreferencesSynchronizer) readImport(data []byte) (exportFile, error) {
+	file := exportFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return file, fmt.Errorf("not a settings file: %w", err)
+	}
+
+	if file.Version > p.version {
+		return file, fmt.Errorf("settings file has version %d, newer than supported %d", file.Version, p.version)
+	}
+
+	if file.Version < p.version {
+		migrated, err := p.migrateImport(file)
+		if err != nil {
+			return file, fmt.Errorf("settings file cannot be migrated: %w", err)
+		}
+
+		file.Preferences = migrated
+	}
+
+	return file, nil
+}
+
+// Returns value of one key from settings file as JSON, migrated the same way
+// as by PrepareImport, so it can be merged with the current one instead of
+// replacing it, false if the file has no such key
+func (p *PreferencesSynchronizer) ImportKey(data []byte, key string) (json.RawMessage, bool, error) {
+	file, err := p.readImport(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	raw, ok := file.Preferences[key]
+	return raw, ok, nil
+}
+
+// Validates settings file and prepares changes it would make
+// File of older schema version is migrated first, newer one is rejected
+func (p *PreferencesSynchronizer) PrepareImport(data []byte) (*ImportPlan, error) {
+	file, err := p.readImport(data)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := ImportPlan{
+		Changes:     make([]ImportChange, 0),
+		UnknownKeys: make([]string, 0),
+		Invalid:     make(map[string]string),
+	}
+
+	keys := make([]string, 0, len(file.Preferences))
+	for key := range file.Preferences {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		raw := file.Preferences[key]
+
+		pref, ok := p.registry[key]
+		if !ok {
+			plan.UnknownKeys = append(plan.UnknownKeys, key)
+			continue
+		}
+
+		apply, changed, err := pref.importJSON(raw)
+		if err != nil {
+			plan.Invalid[key] = err.Error()
+			continue
+		}
+
+		if !changed {
+			continue
+		}
+
+		current, err := pref.exportJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		compacted := bytes.Buffer{}
+		if err := json.Compact(&compacted, raw); err != nil {
+			return nil, err
+		}
+
+		plan.Changes = append(plan.Changes, ImportChange{
+			Key:     key,
+			Current: string(current),
+			New:     compacted.String(),
+		})
+		plan.apply = append(plan.apply, apply)
+	}
+
+	return &plan, nil
+}
+
+// Runs migrations on values of older settings file the same way they run
+// on stored preferences, values are kept in memory storage for that
+// Values are copied as they are, before they are decoded, since keys may be
+// renamed and values of older versions may not decode as current ones
+// Unknown and invalid values are left as they are, to be reported by import
+func (p *PreferencesSynchronizer) migrateImport(file exportFile) (map[string]json.RawMessage, error) {
+	storage := NewMemoryStorage()
+	storage.SetInt(SchemaVersionKey, file.Version)
+
+	copied := make(map[string]interface{})
+	for key, raw := range file.Preferences {
+		if err := storeRawJSON(storage, key, raw); err != nil {
+			return nil, fmt.Errorf("cannot read %s: %w", key, err)
+		}
+
+		copied[key], _ = storage.get(key)
+	}
+
+	if err := p.migrations.Run(storage); err != nil {
+		return nil, err
+	}
+
+	migrated := make(map[string]json.RawMessage)
+
+	// keys removed or renamed by migrations are not imported,
+	// values migrations did not change are imported as they are
+	for key, raw := range file.Preferences {
+		if _, ok := storage.get(key); ok {
+			migrated[key] = raw
+		}
+	}
+
+	// migrations may add keys or change values
+	for key, pref := range p.registry {
+		value, ok := storage.get(key)
+		if !ok {
+			continue
+		}
+
+		if original, ok := copied[key]; ok && original == value {
+			continue
+		}
+
+		raw, err := pref.loadJSON(storage)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read migrated %s: %w", key, err)
+		}
+
+		migrated[key] = raw
+	}
+
+	return migrated, nil
+}
+
+// Keeps JSON value in storage the way codecs keep it, strings, numbers
+// and booleans as they are, arrays and objects as JSON string
+func storeRawJSON(storage Storage, key string, raw json.RawMessage) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case nil:
+	case string:
+		storage.SetString(key, v)
+	case bool:
+		storage.SetBool(key, v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			storage.SetInt(key, int(i))
+			break
+		}
+
+		f, err := v.Float64()
+		if err != nil {
+			return err
+		}
+
+		storage.SetFloat(key, f)
+	default:
+		compacted := bytes.Buffer{}
+		if err := json.Compact(&compacted, raw); err != nil {
+			return err
+		}
+
+		storage.SetString(key, compacted.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package preferences
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"github.com/sharki13/timestamp-converter/xbinding"
+)
+
+func newExportTestSynchronizer(t *testing.T) (*PreferencesSynchronizer, binding.String, xbinding.IntArray) {
+	assert := assert{t}
+
+	prefSync := newTestSynchronizer(t, test.NewApp())
+	format := binding.NewString()
+	timezones := xbinding.NewIntArray()
+
+	assert.NoError(prefSync.AddString(StringPreference{
+		Key:      "format",
+		Value:    format,
+		Fallback: "2006",
+	}), "AddString should not return an error")
+
+	assert.NoError(prefSync.AddIntArray(IntArrayPreference{
+		Key:      "visibleTimezones",
+		Value:    timezones,
+		Fallback: []int{0},
+	}), "AddIntArray should not return an error")
+
+	return prefSync, format, timezones
+}
+
+func TestExport_RoundTrip(t *testing.T) {
+	assert := assert{t}
+
+	source, sourceFormat, sourceTimezones := newExportTestSynchronizer(t)
+	sourceFormat.Set("15:04")
+	sourceTimezones.Set([]int{0, 3})
+
+	data, err := source.Export()
+	assert.NoError(err, "Export should not return an error")
+
+	target, targetFormat, targetTimezones := newExportTestSynchronizer(t)
+
+	plan, err := target.PrepareImport(data)
+	assert.NoError(err, "PrepareImport should not return an error")
+	assert.Equal(2, len(plan.Changes), "Both preferences should change")
+	assert.Equal(0, len(plan.UnknownKeys), "There should be no unknown keys")
+
+	// nothing changes before Apply
+	format, _ := targetFormat.Get()
+	assert.Equal("2006", format, "Format should not change before Apply")
+
+	assert.NoError(plan.Apply(), "Apply should not return an error")
+
+	format, _ = targetFormat.Get()
+	assert.Equal("15:04", format, "Format should be imported")
+
+	timezones, _ := targetTimezones.Get()
+	assert.Equal([]int{0, 3}, timezones, "Timezones should be imported")
+}
+
+func TestImport_Report(t *testing.T) {
+	assert := assert{t}
+
+	prefSync, _, _ := newExportTestSynchronizer(t)
+
+	data := []byte(`{
+		"version": 1,
+		"preferences": {
+			"format": "2006",
+			"visibleTimezones": "not an array",
+			"unknown": true
+		}
+	}`)
+
+	plan, err := prefSync.PrepareImport(data)
+	assert.NoError(err, "PrepareImport should not return an error")
+	assert.Equal(0, len(plan.Changes), "Same format should not be a change")
+	assert.Equal([]string{"unknown"}, plan.UnknownKeys, "Unknown key should be reported")
+	_, invalid := plan.Invalid["visibleTimezones"]
+	assert.True(invalid, "Wrong type should be reported as invalid")
+}
+
+func TestImport_Errors(t *testing.T) {
+	assert := assert{t}
+
+	prefSync, _, _ := newExportTestSynchronizer(t)
+
+	_, err := prefSync.PrepareImport([]byte("not json"))
+	assert.Error(err, "Not JSON should return an error")
+
+	_, err = prefSync.PrepareImport([]byte(`{"version": 99, "preferences": {}}`))
+	assert.Error(err, "Newer version should return an error")
+}
+
+func TestImport_OlderVersion(t *testing.T) {
+	assert := assert{t}
+
+	migrations := Migrations{
+		0: func(Storage) error { return nil },
+		// version 2 keeps formats upper case
+		1: func(prefs Storage) error {
+			prefs.SetString("format", strings.ToUpper(prefs.String("format")))
+			return nil
+		},
+	}
+
+	prefSync, err := NewPreferencesSynchronizerWithStorage(NewMemoryStorage(), migrations)
+	assert.NoError(err, "NewPreferencesSynchronizerWithStorage should not return an error")
+
+	format := binding.NewString()
+	assert.NoError(prefSync.AddString(StringPreference{Key: "format", Value: format, Fallback: "2006"}), "AddString should not return an error")
+
+	plan, err := prefSync.PrepareImport([]byte(`{"version": 1, "preferences": {"format": "jan 2", "unknown": 1}}`))
+	assert.NoError(err, "Older version should be migrated")
+	assert.Equal([]string{"unknown"}, plan.UnknownKeys, "Unknown key should be kept and reported")
+	assert.NoError(plan.Apply(), "Apply should not return an error")
+
+	value, _ := format.Get()
+	assert.Equal("JAN 2", value, "Imported value should be migrated")
+
+	plan, err = prefSync.PrepareImport([]byte(`{"version": 0, "preferences": {"format": "mon 15:04"}}`))
+	assert.NoError(err, "File from before versioning should be migrated")
+	assert.Equal(`"MON 15:04"`, plan.Changes[0].New, "Every migration should run")
+
+	_, err = prefSync.PrepareImport([]byte(`{"version": 3, "preferences": {}}`))
+	assert.Error(err, "Newer version should return an error")
+}
+
+func TestImport_RenamingMigration(t *testing.T) {
+	assert := assert{t}
+
+	prefSync, err := NewPreferencesSynchronizerWithStorage(NewMemoryStorage(), testMigrations)
+	assert.NoError(err, "NewPreferencesSynchronizerWithStorage should not return an error")
+
+	timeFormat := binding.NewString()
+	timezones := binding.NewString()
+	assert.NoError(prefSync.AddString(StringPreference{Key: "timeFormat", Value: timeFormat, Fallback: "2006"}), "AddString should not return an error")
+	assert.NoError(prefSync.AddString(StringPreference{Key: "visibleTimezones", Value: timezones}), "AddString should not return an error")
+
+	// file from before versioning, with old key and list in old format
+	plan, err := prefSync.PrepareImport([]byte(`{"version": 0, "preferences": {"format": "15:04", "visibleTimezones": [3, 5]}}`))
+	assert.NoError(err, "Older version should be migrated")
+	assert.Equal([]string{}, plan.UnknownKeys, "Renamed key should not be reported as unknown")
+	assert.Equal(0, len(plan.Invalid), "Values of older version should not be reported as invalid")
+	assert.NoError(plan.Apply(), "Apply should not return an error")
+
+	value, _ := timeFormat.Get()
+	assert.Equal("15:04", value, "Value of renamed key should be imported")
+
+	value, _ = timezones.Get()
+	assert.Equal("3,5", value, "Value in old format should be converted")
+}
+
+func TestExportKeys(t *testing.T) {
+	assert := assert{t}
+
+	source, sourceFormat, sourceTimezones := newExportTestSynchronizer(t)
+	sourceFormat.Set("15:04")
+	sourceTimezones.Set([]int{0, 3})
+
+	data, err := source.ExportKeys([]string{"visibleTimezones"})
+	assert.NoError(err, "ExportKeys should not return an error")
+
+	_, err = source.ExportKeys([]string{"unknown"})
+	assert.Error(err, "ExportKeys of unregistered key should return an error")
+
+	target, targetFormat, targetTimezones := newExportTestSynchronizer(t)
+
+	plan, err := target.PrepareImport(data)
+	assert.NoError(err, "PrepareImport should not return an error")
+	assert.Equal(1, len(plan.Changes), "Only exported preference should change")
+	assert.NoError(plan.Apply(), "Apply should not return an error")
+
+	format, _ := targetFormat.Get()
+	assert.Equal("2006", format, "Format was not exported, so it should not change")
+
+	timezones, _ := targetTimezones.Get()
+	assert.Equal([]int{0, 3}, timezones, "Timezones should be imported")
+}
+
+func TestImportKey(t *testing.T) {
+	assert := assert{t}
+
+	source, sourceFormat, sourceTimezones := newExportTestSynchronizer(t)
+	sourceFormat.Set("15:04")
+	sourceTimezones.Set([]int{0, 3})
+
+	data, err := source.ExportKeys([]string{"visibleTimezones"})
+	assert.NoError(err, "ExportKeys should not return an error")
+
+	target, _, targetTimezones := newExportTestSynchronizer(t)
+
+	raw, ok, err := target.ImportKey(data, "visibleTimezones")
+	assert.NoError(err, "ImportKey should not return an error")
+	assert.True(ok, "Exported key should be found")
+	assert.Equal("[0,3]", strings.Join(strings.Fields(string(raw)), ""), "Value should be returned as JSON")
+
+	timezones, _ := targetTimezones.Get()
+	assert.Equal([]int{0}, timezones, "ImportKey should not change the value")
+
+	_, ok, err = target.ImportKey(data, "format")
+	assert.NoError(err, "ImportKey of missing key should not return an error")
+	assert.False(ok, "Key which was not exported should not be found")
+
+	_, _, err = target.ImportKey([]byte("not json"), "format")
+	assert.Error(err, "ImportKey of invalid file should return an error")
+}
@@ -0,0 +1,63 @@
+package preferences
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Key under which schema version of stored preferences is kept,
+// preferences saved before versioning have no such key, that is version 0
+const SchemaVersionKey = "schemaVersion"
+
+// Migration upgrades stored preferences by one version
+type Migration func(prefs Storage) error
+
+// Registry of migrations, key is the version migration upgrades from,
+// so migration under key N turns version N into N+1
+type Migrations map[int]Migration
+
+// Migrations of the app preferences
+var DefaultMigrations = Migrations{
+	// version 1 is the layout used before versioning,
+	// nothing to change, only the version gets stored
+	0: func(Storage) error { return nil },
+}
+
+// Returns version preferences have after all migrations
+func (m Migrations) Latest() int {
+	latest := 0
+	for from := range m {
+		if from+1 > latest {
+			latest = from + 1
+		}
+	}
+
+	return latest
+}
+
+// Runs migrations from stored version up to the latest one,
+// version is stored after each step so failed run resumes where it stopped
+func (m Migrations) Run(prefs Storage) error {
+	latest := m.Latest()
+	version := prefs.IntWithFallback(SchemaVersionKey, 0)
+
+	if version > latest {
+		return fmt.Errorf("stored preferences have version %d, newer than supported %d", version, latest)
+	}
+
+	for ; version < latest; version++ {
+		migration, ok := m[version]
+		if !ok {
+			return fmt.Errorf("no migration from version %d", version)
+		}
+
+		if err := migration(prefs); err != nil {
+			return fmt.Errorf("migration from version %d failed: %w", version, err)
+		}
+
+		prefs.SetInt(SchemaVersionKey, version+1)
+		slog.Info("preferences migrated", "from", version, "to", version+1)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package preferences
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"github.com/sharki13/timestamp-converter/xbinding"
+)
+
+// Example migrations, v1 renames "format" to "timeFormat",
+// v2 changes "visibleTimezones" from JSON array to comma separated list
+var testMigrations = Migrations{
+	0: func(Storage) error { return nil },
+	1: func(prefs Storage) error {
+		format := prefs.String("format")
+		if format == "" {
+			return nil
+		}
+
+		prefs.SetString("timeFormat", format)
+		prefs.RemoveValue("format")
+		return nil
+	},
+	2: func(prefs Storage) error {
+		serialized := prefs.String("visibleTimezones")
+		if serialized == "" {
+			return nil
+		}
+
+		ids := make([]int, 0)
+		if err := json.Unmarshal([]byte(serialized), &ids); err != nil {
+			return err
+		}
+
+		list := ""
+		for i, id := range ids {
+			if i > 0 {
+				list += ","
+			}
+			list += fmt.Sprint(id)
+		}
+
+		prefs.SetString("visibleTimezones", list)
+		return nil
+	},
+}
+
+func TestMigrations_Latest(t *testing.T) {
+	assert := assert{t}
+
+	assert.Equal(0, Migrations{}.Latest(), "Empty registry should be version 0")
+	assert.Equal(1, DefaultMigrations.Latest(), "Default migrations should be version 1")
+	assert.Equal(3, testMigrations.Latest(), "Test migrations should be version 3")
+}
+
+func TestMigrations_FromUnversioned(t *testing.T) {
+	assert := assert{t}
+	testApp := test.NewApp()
+
+	testApp.Preferences().SetString("format", "2006")
+	testApp.Preferences().SetString("visibleTimezones", "[3, 5, 8]")
+
+	prefSync, err := NewPreferencesSynchronizerWithMigrations(testApp, testMigrations)
+	assert.NoError(err, "Migrations should not return an error")
+
+	assert.Equal(3, testApp.Preferences().Int(SchemaVersionKey), "Version should be stored")
+	assert.Equal("", testApp.Preferences().String("format"), "Old key should be removed")
+	assert.Equal("3,5,8", testApp.Preferences().String("visibleTimezones"), "Value should be converted")
+
+	timeFormat := binding.NewString()
+	err = prefSync.AddString(StringPreference{
+		Key:      "timeFormat",
+		Value:    timeFormat,
+		Fallback: "",
+	})
+	assert.NoError(err, "AddString should not return an error")
+
+	value, err := timeFormat.Get()
+	assert.NoError(err, "Get should not return an error")
+	assert.Equal("2006", value, "Binding should load migrated value")
+}
+
+func TestMigrations_FreshInstall(t *testing.T) {
+	assert := assert{t}
+	testApp := test.NewApp()
+
+	_, err := NewPreferencesSynchronizerWithMigrations(testApp, testMigrations)
+	assert.NoError(err, "Migrations should not return an error")
+
+	assert.Equal(3, testApp.Preferences().Int(SchemaVersionKey), "Version should be stored")
+	assert.Equal("", testApp.Preferences().String("visibleTimezones"), "Nothing should be created")
+}
+
+func TestMigrations_PartiallyMigrated(t *testing.T) {
+	assert := assert{t}
+	testApp := test.NewApp()
+
+	// already at version 2, only the last migration has to run
+	testApp.Preferences().SetInt(SchemaVersionKey, 2)
+	testApp.Preferences().SetString("format", "2006")
+	testApp.Preferences().SetString("visibleTimezones", "[1]")
+
+	_, err := NewPreferencesSynchronizerWithMigrations(testApp, testMigrations)
+	assert.NoError(err, "Migrations should not return an error")
+
+	assert.Equal("2006", testApp.Preferences().String("format"), "Migration 1 should not run again")
+	assert.Equal("1", testApp.Preferences().String("visibleTimezones"), "Migration 2 should run")
+}
+
+func TestMigrations_Errors(t *testing.T) {
+	assert := assert{t}
+
+	newerApp := test.NewApp()
+	newerApp.Preferences().SetInt(SchemaVersionKey, 10)
+	_, err := NewPreferencesSynchronizerWithMigrations(newerApp, testMigrations)
+	assert.Error(err, "Newer stored version should return an error")
+
+	gapApp := test.NewApp()
+	_, err = NewPreferencesSynchronizerWithMigrations(gapApp, Migrations{0: testMigrations[0], 2: testMigrations[2]})
+	assert.Error(err, "Missing migration should return an error")
+	assert.Equal(1, gapApp.Preferences().Int(SchemaVersionKey), "Version should stop before the gap")
+
+	failingApp := test.NewApp()
+	failingApp.Preferences().SetString("visibleTimezones", "not json")
+	_, err = NewPreferencesSynchronizerWithMigrations(failingApp, testMigrations)
+	assert.Error(err, "Failing migration should return an error")
+	assert.Equal(2, failingApp.Preferences().Int(SchemaVersionKey), "Version should stop at failed migration")
+
+	prefSync := newTestSynchronizer(t, test.NewApp())
+	err = prefSync.AddIntArray(IntArrayPreference{
+		Key:      SchemaVersionKey,
+		Value:    xbinding.NewIntArray(),
+		Fallback: []int{},
+	})
+	assert.Error(err, "Schema version key should be reserved")
+}
+
+func TestMigrations_NewerKeptInMemory(t *testing.T) {
+	assert := assert{t}
+
+	newerApp := test.NewApp()
+	newerApp.Preferences().SetInt(SchemaVersionKey, 10)
+	newerApp.Preferences().SetString("format", "2006")
+
+	prefSync := NewPreferencesSynchronizer(newerApp)
+
+	format := binding.NewString()
+	assert.NoError(prefSync.AddString(StringPreference{Key: "format", Value: format, Fallback: "15:04"}), "AddString should not return an error")
+	format.Set("Jan 2")
+	waitForListeners(t)
+	assert.NoError(prefSync.Flush(), "Flush should not return an error")
+
+	assert.Equal("2006", newerApp.Preferences().String("format"), "Newer preferences should be left untouched")
+	assert.Equal(10, newerApp.Preferences().Int(SchemaVersionKey), "Newer version should be kept")
+}
@@ -3,6 +3,11 @@ package preferences
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/data/binding"
@@ -19,6 +24,9 @@ type StringPreference struct {
 	Key      string
 	Value    binding.String
 	Fallback string
+	// optional, see Preference
+	Validate  func(string) error
+	Normalize func(string) string
 }
 
 func (s StringPreference) GetKey() string {
@@ -31,6 +39,9 @@ type IntPreference struct {
 	Key      string
 	Value    binding.Int
 	Fallback int
+	// optional, see Preference
+	Validate  func(int) error
+	Normalize func(int) int
 }
 
 func (i IntPreference) GetKey() string {
@@ -41,6 +52,9 @@ type IntArrayPreference struct {
 	Key      string
 	Value    xbinding.IntArray
 	Fallback []int
+	// optional, see Preference
+	Validate  func([]int) error
+	Normalize func([]int) []int
 }
 
 func (i IntArrayPreference) GetKey() string {
@@ -53,176 +67,767 @@ type BoolPreference struct {
 	Key      string
 	Value    binding.Bool
 	Fallback bool
+	// optional, see Preference
+	Validate  func(bool) error
+	Normalize func(bool) bool
 }
 
 func (b BoolPreference) GetKey() string {
 	return b.Key
 }
 
+// Preference that is stored as ISO 8601 duration, e.g. "PT1H30M"
+// key: the key of the preference, has to be unique across all preferences
+type DurationPreference struct {
+	Key      string
+	Value    xbinding.Duration
+	Fallback time.Duration
+	// optional, see Preference
+	Validate  func(time.Duration) error
+	Normalize func(time.Duration) time.Duration
+}
+
+func (d DurationPreference) GetKey() string {
+	return d.Key
+}
+
+// Preference that is stored as IANA name of the location, e.g. "Europe/Warsaw"
+// key: the key of the preference, has to be unique across all preferences
+type LocationPreference struct {
+	Key      string
+	Value    xbinding.Location
+	Fallback *time.Location
+	// optional, see Preference
+	Validate  func(*time.Location) error
+	Normalize func(*time.Location) *time.Location
+}
+
+func (l LocationPreference) GetKey() string {
+	return l.Key
+}
+
+// Value which can be bound to a preference,
+// fyne typed bindings and xbinding types fit it
+type Bindable[T any] interface {
+	Get() (T, error)
+	Set(T) error
+	AddListener(binding.DataListener)
+	RemoveListener(binding.DataListener)
+}
+
+// Preference of any type, Codec decides how it is stored
+// key: the key of the preference, has to be unique across all preferences
+// Normalize and Validate are optional, they run on load and on every set,
+// value is normalized first, invalid value is replaced with fallback
+// and reported to the error handler instead of being stored
+type Preference[T any] struct {
+	Key       string
+	Value     Bindable[T]
+	Fallback  T
+	Codec     Codec[T]
+	Validate  func(T) error
+	Normalize func(T) T
+}
+
+func (p Preference[T]) GetKey() string {
+	return p.Key
+}
+
+// Returns normalized value, or error if it is not valid
+func (p Preference[T]) check(value T) (T, error) {
+	if p.Normalize != nil {
+		value = p.Normalize(value)
+	}
+
+	if p.Validate != nil {
+		if err := p.Validate(value); err != nil {
+			return value, &ValidationError{Key: p.Key, Value: value, Err: err}
+		}
+	}
+
+	return value, nil
+}
+
+// Returns current value of the binding as JSON
+func (p Preference[T]) exportJSON() (json.RawMessage, error) {
+	v, err := p.Value.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.marshal(v)
+}
+
+// Encodes value as JSON, the way codec wants if it has its own
+func (p Preference[T]) marshal(value T) (json.RawMessage, error) {
+	if c, ok := p.Codec.(JSONCodecOverride[T]); ok {
+		return c.MarshalJSONValue(value)
+	}
+
+	return json.Marshal(value)
+}
+
+func (p Preference[T]) unmarshal(raw json.RawMessage) (T, error) {
+	if c, ok := p.Codec.(JSONCodecOverride[T]); ok {
+		return c.UnmarshalJSONValue(raw)
+	}
+
+	var value T
+	err := json.Unmarshal(raw, &value)
+	return value, err
+}
+
+// Decodes value from JSON, returns function which sets it
+// and tells if it differs from the current one
+func (p Preference[T]) importJSON(raw json.RawMessage) (func() error, bool, error) {
+	v, err := p.unmarshal(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	v, err = p.check(v)
+	if err != nil {
+		return nil, false, err
+	}
+
+	current, err := p.Value.Get()
+	if err != nil {
+		return nil, false, err
+	}
+
+	apply := func() error {
+		return p.Value.Set(v)
+	}
+
+	return apply, !reflect.DeepEqual(current, v), nil
+}
+
+// Stores value given as JSON with the codec, the way it is kept in storage
+func (p Preference[T]) storeJSON(storage Storage, raw json.RawMessage) error {
+	v, err := p.unmarshal(raw)
+	if err != nil {
+		return err
+	}
+
+	return p.Codec.Store(storage, p.Key, v)
+}
+
+// Returns value kept in storage as JSON
+func (p Preference[T]) loadJSON(storage Storage) (json.RawMessage, error) {
+	v, err := p.Codec.Load(storage, p.Key, p.Fallback)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.marshal(v)
+}
+
+// Returns fallback value as JSON
+func (p Preference[T]) fallbackJSON() (json.RawMessage, error) {
+	return p.marshal(p.Fallback)
+}
+
+func (p Preference[T]) addListener(listener binding.DataListener) {
+	p.Value.AddListener(listener)
+}
+
+func (p Preference[T]) removeListener(listener binding.DataListener) {
+	p.Value.RemoveListener(listener)
+}
+
+// Sets binding to the fallback value
+func (p Preference[T]) reset() error {
+	return p.Value.Set(p.Fallback)
+}
+
+// Preference kept in the registry, whatever its type is
+type registered interface {
+	Keyed
+	exportJSON() (json.RawMessage, error)
+	importJSON(raw json.RawMessage) (func() error, bool, error)
+	storeJSON(storage Storage, raw json.RawMessage) error
+	loadJSON(storage Storage) (json.RawMessage, error)
+	fallbackJSON() (json.RawMessage, error)
+	addListener(listener binding.DataListener)
+	removeListener(listener binding.DataListener)
+	reset() error
+}
+
 // PreferencesSynchronizer is used to sync preferences
-// between bindings and the fyne preferences
+// between bindings and the storage, fyne preferences by default
+// If storage is WatchedStorage, changes made in it by someone else
+// are synced back to the bindings
 type PreferencesSynchronizer struct {
-	stringPreferences   []StringPreference
-	intPreferences      []IntPreference
-	boolPreferences     []BoolPreference
-	intArrayPreferences []IntArrayPreference
-	app                 fyne.App
+	registry map[string]registered
+	// listeners which store values, by key
+	listeners map[string]binding.DataListener
+	// functions which set bindings to stored values, by key
+	reloads map[string]func() error
+	storage Storage
+	version int
+	// run on stored preferences and on imported files of older versions
+	migrations Migrations
+	// called with errors which cannot be returned to the caller,
+	// values which failed validation or writes which failed, logs them by default
+	errorHandler func(err error)
+
+	// writes waiting for debounce, latest value is read when they run
+	pending      map[string]func() error
+	pendingOrder []string
+	debounce     time.Duration
+	timer        *time.Timer
+	// writes are held while it is above zero, see holdWrites
+	held int
+	lock sync.Mutex
+	// writes come from listeners, the debounce timer and callers, fyne
+	// preferences cannot notify about concurrent ones, so they go one by one
+	writeLock sync.Mutex
 }
 
 // Creates a new preferences sync
 // that can be used to sync preferences with the fyne preferences
+// Stored preferences are migrated with DefaultMigrations, if they cannot be,
+// they are left untouched and values are kept in memory only
 // Remark: all bindings have to be initialized before calling this function
 func NewPreferencesSynchronizer(app fyne.App) *PreferencesSynchronizer {
+	pref, err := NewPreferencesSynchronizerWithMigrations(app, DefaultMigrations)
+	if err != nil {
+		slog.Error("preferences will not be saved", "err", err)
+		return NewMemoryPreferencesSynchronizer(DefaultMigrations)
+	}
+
+	return pref
+}
+
+// Creates a new preferences sync
+// that can be used to sync preferences with the fyne preferences
+// Stored preferences are migrated to the latest schema version first,
+// error is returned if they cannot be, e.g. they are newer than the app
+// Remark: all bindings have to be initialized before calling this function
+func NewPreferencesSynchronizerWithMigrations(app fyne.App, migrations Migrations) (*PreferencesSynchronizer, error) {
+	return NewPreferencesSynchronizerWithStorage(app.Preferences(), migrations)
+}
+
+// Creates a new preferences sync on top of any storage, e.g. FileStorage
+// for tools without fyne app, or MemoryStorage for tests
+func NewPreferencesSynchronizerWithStorage(storage Storage, migrations Migrations) (*PreferencesSynchronizer, error) {
+	if err := migrations.Run(storage); err != nil {
+		return nil, err
+	}
+
+	return newPreferencesSynchronizer(storage, migrations), nil
+}
+
+// Creates a new preferences sync which keeps values in memory only,
+// e.g. when stored preferences cannot be used, empty storage needs
+// no migrations, so it cannot fail
+func NewMemoryPreferencesSynchronizer(migrations Migrations) *PreferencesSynchronizer {
+	storage := NewMemoryStorage()
+	storage.SetInt(SchemaVersionKey, migrations.Latest())
+
+	return newPreferencesSynchronizer(storage, migrations)
+}
+
+func newPreferencesSynchronizer(storage Storage, migrations Migrations) *PreferencesSynchronizer {
 	pref := PreferencesSynchronizer{
-		app: app,
+		storage:    storage,
+		version:    migrations.Latest(),
+		migrations: migrations,
+		errorHandler: func(err error) {
+			slog.Error("preference error", "err", err)
+		},
 	}
 
-	pref.stringPreferences = make([]StringPreference, 0)
-	pref.intPreferences = make([]IntPreference, 0)
-	pref.boolPreferences = make([]BoolPreference, 0)
-	pref.intArrayPreferences = make([]IntArrayPreference, 0)
+	pref.registry = make(map[string]registered)
+	pref.listeners = make(map[string]binding.DataListener)
+	pref.reloads = make(map[string]func() error)
+	pref.pending = make(map[string]func() error)
+
+	if watched, ok := storage.(WatchedStorage); ok {
+		watched.AddChangeListener(func() {
+			if err := pref.Reload(); err != nil {
+				pref.reportError(err)
+			}
+		})
+	}
 
 	return &pref
 }
 
-// Adds a new string preference to the synchronizer
+// Adds a new preference of any type to the synchronizer
 // and sets the value to the current value of the preference
 // or the fallback value if the preference is not set
-func (p *PreferencesSynchronizer) AddString(e StringPreference) error {
+// It is a function, not a method, because methods cannot have type parameters
+func Add[T any](p *PreferencesSynchronizer, e Preference[T]) error {
 	if p.isKeyExisting(e.Key) {
 		return fmt.Errorf("key %s is already in use", e.Key)
 	}
 
-	e.Value.Set(p.app.Preferences().StringWithFallback(e.Key, e.Fallback))
+	if e.Key == SchemaVersionKey {
+		return fmt.Errorf("key %s is reserved", e.Key)
+	}
 
-	p.stringPreferences = append(p.stringPreferences, e)
+	if e.Codec == nil {
+		return fmt.Errorf("key %s has no codec", e.Key)
+	}
 
-	e.Value.AddListener(binding.NewDataListener(func() {
-		v, err := e.Value.Get()
+	// invalid fallback would be replaced with itself forever
+	if _, err := e.check(e.Fallback); err != nil {
+		return fmt.Errorf("fallback of %s: %w", e.Key, err)
+	}
+
+	// corrupted value would make the app unusable, so it is dropped
+	value, err := e.Codec.Load(p.storage, e.Key, e.Fallback)
+	if err != nil {
+		slog.Warn("preference cannot be read, falling back to default", "key", e.Key, "err", err)
+		p.removeStored(e.Key)
+		value = e.Fallback
+	}
+
+	if value, err = e.check(value); err != nil {
+		p.reportError(err)
+		p.removeStored(e.Key)
+		value = e.Fallback
+	}
+
+	if err := e.Value.Set(value); err != nil {
+		return err
+	}
+
+	slog.Debug("preference loaded", "key", e.Key, "value", value)
+	p.registry[e.Key] = e
+
+	// value last written to or read from the storage, it tells own writes
+	// apart from changes made by someone else, so a value does not go back and forth
+	last := value
+	var lastLock sync.Mutex
+
+	// value is read while writing, so a write which waited
+	// for another one does not store outdated value
+	store := func() error {
+		return p.write(func() error {
+			v, err := e.Value.Get()
+			if err != nil {
+				return err
+			}
+
+			// set before storing, storage may notify about the change right away
+			lastLock.Lock()
+			last = v
+			lastLock.Unlock()
+
+			slog.Debug("preference stored", "key", e.Key, "value", v)
+			return e.Codec.Store(p.storage, e.Key, v)
+		})
+	}
+
+	reload := func() error {
+		stored, err := e.Codec.Load(p.storage, e.Key, e.Fallback)
 		if err != nil {
-			panic(err)
+			slog.Warn("preference cannot be reloaded", "key", e.Key, "err", err)
+			return nil
 		}
 
-		p.app.Preferences().SetString(e.Key, v)
-	}))
+		// value written by someone else is ignored, own one stays stored
+		if stored, err = e.check(stored); err != nil {
+			p.reportError(err)
+			return nil
+		}
 
-	return nil
-}
+		lastLock.Lock()
+		// own change waiting for debounce is newer
+		changed := !reflect.DeepEqual(stored, last) && !p.isPending(e.Key)
+		if changed {
+			last = stored
+		}
+		lastLock.Unlock()
 
-// Adds a new int preference to the synchronizer
-// and sets the value to the current value of the preference
-// or the fallback value if the preference is not set
-func (p *PreferencesSynchronizer) AddInt(e IntPreference) error {
-	if p.isKeyExisting(e.Key) {
-		return fmt.Errorf("key %s is already in use", e.Key)
-	}
+		// not locked while set, listeners of the binding may store it right away
+		if !changed {
+			return nil
+		}
 
-	e.Value.Set(p.app.Preferences().IntWithFallback(e.Key, e.Fallback))
+		current, err := e.Value.Get()
+		if err != nil {
+			return err
+		}
 
-	p.intPreferences = append(p.intPreferences, e)
+		if reflect.DeepEqual(stored, current) {
+			return nil
+		}
 
-	e.Value.AddListener(binding.NewDataListener(func() {
+		return e.Value.Set(stored)
+	}
+
+	listener := binding.NewDataListener(func() {
 		v, err := e.Value.Get()
 		if err != nil {
-			panic(err)
+			p.reportError(fmt.Errorf("cannot read %s: %w", e.Key, err))
+			return
+		}
+
+		// corrected value triggers the listener again and is stored then
+		normalized, err := e.check(v)
+		if err != nil {
+			p.reportError(err)
+			normalized = e.Fallback
+		}
+
+		if !reflect.DeepEqual(normalized, v) {
+			if err := e.Value.Set(normalized); err != nil {
+				p.reportError(fmt.Errorf("cannot correct %s: %w", e.Key, err))
+			}
+
+			return
+		}
+
+		if err := p.schedule(e.Key, store); err != nil {
+			p.reportError(fmt.Errorf("cannot store %s: %w", e.Key, err))
 		}
+	})
+
+	p.listeners[e.Key] = listener
+	e.Value.AddListener(listener)
 
-		p.app.Preferences().SetInt(e.Key, v)
-	}))
+	p.lock.Lock()
+	p.reloads[e.Key] = reload
+	p.lock.Unlock()
 
 	return nil
 }
 
+// Adds a new string preference to the synchronizer
+// and sets the value to the current value of the preference
+// or the fallback value if the preference is not set
+func (p *PreferencesSynchronizer) AddString(e StringPreference) error {
+	return Add[string](p, Preference[string]{
+		Key:       e.Key,
+		Value:     e.Value,
+		Fallback:  e.Fallback,
+		Codec:     StringCodec{},
+		Validate:  e.Validate,
+		Normalize: e.Normalize,
+	})
+}
+
+// Adds a new int preference to the synchronizer
+// and sets the value to the current value of the preference
+// or the fallback value if the preference is not set
+func (p *PreferencesSynchronizer) AddInt(e IntPreference) error {
+	return Add[int](p, Preference[int]{
+		Key:       e.Key,
+		Value:     e.Value,
+		Fallback:  e.Fallback,
+		Codec:     IntCodec{},
+		Validate:  e.Validate,
+		Normalize: e.Normalize,
+	})
+}
+
 // Adds a new bool preference to the synchronizer
 // and sets the value to the current value of the preference
 // or the fallback value if the preference is not set
 func (p *PreferencesSynchronizer) AddBool(e BoolPreference) error {
-	if p.isKeyExisting(e.Key) {
-		return fmt.Errorf("key %s is already in use", e.Key)
+	return Add[bool](p, Preference[bool]{
+		Key:       e.Key,
+		Value:     e.Value,
+		Fallback:  e.Fallback,
+		Codec:     BoolCodec{},
+		Validate:  e.Validate,
+		Normalize: e.Normalize,
+	})
+}
+
+// Adds a new int array preference to the synchronizer
+// and sets the value to the current value of the preference
+// or the fallback value if the preference is not set
+func (p *PreferencesSynchronizer) AddIntArray(e IntArrayPreference) error {
+	return Add[[]int](p, Preference[[]int]{
+		Key:       e.Key,
+		Value:     &e.Value,
+		Fallback:  e.Fallback,
+		Codec:     intArrayCodec{},
+		Validate:  e.Validate,
+		Normalize: e.Normalize,
+	})
+}
+
+// Adds a new duration preference to the synchronizer
+// and sets the value to the current value of the preference
+// or the fallback value if the preference is not set
+func (p *PreferencesSynchronizer) AddDuration(e DurationPreference) error {
+	return Add[time.Duration](p, Preference[time.Duration]{
+		Key:       e.Key,
+		Value:     &e.Value,
+		Fallback:  e.Fallback,
+		Codec:     ISODurationCodec{},
+		Validate:  e.Validate,
+		Normalize: e.Normalize,
+	})
+}
+
+// Adds a new location preference to the synchronizer
+// and sets the value to the current value of the preference
+// or the fallback value if the preference is not set
+func (p *PreferencesSynchronizer) AddLocation(e LocationPreference) error {
+	// nil location cannot be stored
+	if e.Fallback == nil {
+		return fmt.Errorf("fallback of %s cannot be nil", e.Key)
 	}
 
-	e.Value.Set(p.app.Preferences().BoolWithFallback(e.Key, e.Fallback))
+	return Add[*time.Location](p, Preference[*time.Location]{
+		Key:       e.Key,
+		Value:     &e.Value,
+		Fallback:  e.Fallback,
+		Codec:     LocationCodec{},
+		Validate:  e.Validate,
+		Normalize: e.Normalize,
+	})
+}
 
-	p.boolPreferences = append(p.boolPreferences, e)
+// Returns keys of all registered preferences
+func (p *PreferencesSynchronizer) Keys() []string {
+	keys := make([]string, 0, len(p.registry))
+	for key := range p.registry {
+		keys = append(keys, key)
+	}
 
-	e.Value.AddListener(binding.NewDataListener(func() {
-		v, err := e.Value.Get()
-		if err != nil {
-			panic(err)
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Sets function called with values rejected by validators and errors
+// of listeners, reloads and debounced writes, they are only logged by default
+func (p *PreferencesSynchronizer) SetErrorHandler(handler func(err error)) {
+	p.lock.Lock()
+	p.errorHandler = handler
+	p.lock.Unlock()
+}
+
+func (p *PreferencesSynchronizer) reportError(err error) {
+	p.lock.Lock()
+	handler := p.errorHandler
+	p.lock.Unlock()
+
+	if handler != nil {
+		handler(err)
+	}
+}
+
+// Sets how long writes wait for further changes before they are stored,
+// changes of the same key in that time are coalesced into a single write
+// Zero, the default, stores every change right away
+func (p *PreferencesSynchronizer) SetDebounce(delay time.Duration) {
+	p.lock.Lock()
+	p.debounce = delay
+	p.lock.Unlock()
+}
+
+// Stores write right away, or queues it when debounce is set
+func (p *PreferencesSynchronizer) schedule(key string, store func() error) error {
+	p.lock.Lock()
+
+	if p.debounce == 0 && p.held == 0 {
+		p.lock.Unlock()
+		return store()
+	}
+
+	if _, ok := p.pending[key]; !ok {
+		p.pendingOrder = append(p.pendingOrder, key)
+	}
+	p.pending[key] = store
+
+	if p.held == 0 {
+		p.restartTimer()
+	}
+
+	p.lock.Unlock()
+
+	return nil
+}
+
+// Starts debounce again, lock has to be held
+func (p *PreferencesSynchronizer) restartTimer() {
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+
+	p.timer = time.AfterFunc(p.debounce, func() {
+		if err := p.Flush(); err != nil {
+			p.reportError(err)
 		}
+	})
+}
+
+// Holds writes until releaseWrites, so values changed together
+// are stored together, calls can be nested
+func (p *PreferencesSynchronizer) holdWrites() {
+	p.lock.Lock()
+	p.held++
+	p.lock.Unlock()
+}
 
-		p.app.Preferences().SetBool(e.Key, v)
-	}))
+// Stores writes held since holdWrites, right away or after debounce
+func (p *PreferencesSynchronizer) releaseWrites() error {
+	p.lock.Lock()
+
+	p.held--
+	if p.held > 0 || len(p.pending) == 0 {
+		p.lock.Unlock()
+		return nil
+	}
+
+	if p.debounce == 0 {
+		p.lock.Unlock()
+		return p.Flush()
+	}
+
+	p.restartTimer()
+	p.lock.Unlock()
 
 	return nil
 }
 
-// Adds a new int array preference to the synchronizer
-// and sets the value to the current value of the preference
-// or the fallback value if the preference is not set
-func (p *PreferencesSynchronizer) AddIntArray(e IntArrayPreference) error {
-	if p.isKeyExisting(e.Key) {
-		return fmt.Errorf("key %s is already in use", e.Key)
+func (p *PreferencesSynchronizer) isPending(key string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	_, ok := p.pending[key]
+	return ok
+}
+
+// Sets bindings to values in the storage which were changed by someone else,
+// called on every change of WatchedStorage, others may call it themselves
+func (p *PreferencesSynchronizer) Reload() error {
+	p.lock.Lock()
+	keys := make([]string, 0, len(p.reloads))
+	reloads := make(map[string]func() error, len(p.reloads))
+	for key, reload := range p.reloads {
+		keys = append(keys, key)
+		reloads[key] = reload
 	}
+	p.lock.Unlock()
 
-	serialized := p.app.Preferences().StringWithFallback(e.Key, "[]")
-	deserialized := make([]int, 0)
+	sort.Strings(keys)
 
-	if serialized != "[]" {
-		if err := json.Unmarshal([]byte(serialized), &deserialized); err != nil {
-			return err
+	for _, key := range keys {
+		if err := reloads[key](); err != nil {
+			return fmt.Errorf("cannot reload %s: %w", key, err)
 		}
-	} else {
-		deserialized = e.Fallback
 	}
 
-	e.Value.Set(deserialized)
+	return nil
+}
 
-	p.intArrayPreferences = append(p.intArrayPreferences, e)
+// Stores all writes waiting for debounce, should be called before the app exits
+// Writes held by holdWrites stay waiting, they are stored when released
+func (p *PreferencesSynchronizer) Flush() error {
+	p.lock.Lock()
 
-	e.Value.AddListener(binding.NewDataListener(func() {
-		v, err := e.Value.Get()
-		if err != nil {
-			panic(err)
-		}
+	if p.held > 0 {
+		p.lock.Unlock()
+		return nil
+	}
 
-		serialized, err := json.Marshal(v)
-		if err != nil {
-			panic(err)
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+
+	pending := p.pending
+	order := p.pendingOrder
+	p.pending = make(map[string]func() error)
+	p.pendingOrder = nil
+
+	p.lock.Unlock()
+
+	for _, key := range order {
+		// removed while waiting
+		store, ok := pending[key]
+		if !ok {
+			continue
 		}
 
-		p.app.Preferences().SetString(e.Key, string(serialized))
-	}))
+		if err := store(); err != nil {
+			return fmt.Errorf("cannot store %s: %w", key, err)
+		}
+	}
 
 	return nil
 }
 
-func isKeyExistInCollection[T Keyed](key string, collection []T) bool {
-	for _, pref := range collection {
-		if pref.GetKey() == key {
-			return true
-		}
+// Unregisters preference and removes its stored value,
+// binding keeps its value, but it is no longer stored
+func (p *PreferencesSynchronizer) Remove(key string) error {
+	pref, ok := p.registry[key]
+	if !ok {
+		return fmt.Errorf("key %s is not registered", key)
 	}
 
-	return false
+	pref.removeListener(p.listeners[key])
+	delete(p.listeners, key)
+	delete(p.registry, key)
+
+	p.lock.Lock()
+	delete(p.pending, key)
+	delete(p.reloads, key)
+	p.lock.Unlock()
+
+	p.removeStored(key)
+
+	return nil
 }
 
-func (p *PreferencesSynchronizer) isKeyExisting(key string) bool {
-	if exist := isKeyExistInCollection(key, p.stringPreferences); exist {
-		return true
-	}
+// Runs write to the storage, one at a time
+func (p *PreferencesSynchronizer) write(write func() error) error {
+	p.writeLock.Lock()
+	defer p.writeLock.Unlock()
 
-	if exist := isKeyExistInCollection(key, p.intPreferences); exist {
-		return true
+	return write()
+}
+
+func (p *PreferencesSynchronizer) removeStored(key string) {
+	p.write(func() error {
+		p.storage.RemoveValue(key)
+		return nil
+	})
+}
+
+// Sets preference back to its fallback value
+func (p *PreferencesSynchronizer) Reset(key string) error {
+	pref, ok := p.registry[key]
+	if !ok {
+		return fmt.Errorf("key %s is not registered", key)
 	}
 
-	if exist := isKeyExistInCollection(key, p.boolPreferences); exist {
-		return true
+	return pref.reset()
+}
+
+// Sets all preferences back to their fallback values
+func (p *PreferencesSynchronizer) ResetAll() error {
+	return p.ResetKeys(p.Keys())
+}
+
+// Sets given preferences back to their fallback values, e.g. settings
+// without user data, nothing is reset if any key is not registered
+func (p *PreferencesSynchronizer) ResetKeys(keys []string) error {
+	for _, key := range keys {
+		if !p.isKeyExisting(key) {
+			return fmt.Errorf("key %s is not registered", key)
+		}
 	}
 
-	if exist := isKeyExistInCollection(key, p.intArrayPreferences); exist {
-		return true
+	for _, key := range keys {
+		if err := p.Reset(key); err != nil {
+			return err
+		}
 	}
 
-	return false
+	return nil
+}
+
+func (p *PreferencesSynchronizer) isKeyExisting(key string) bool {
+	_, exist := p.registry[key]
+	return exist
 }
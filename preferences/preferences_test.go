@@ -1,8 +1,15 @@
 package preferences
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/test"
 	"github.com/sharki13/timestamp-converter/xbinding"
@@ -62,6 +69,29 @@ func (a *assert) NoError(err error, message string) {
 	}
 }
 
+// Synchronizer of app preferences with default migrations
+func newTestSynchronizer(t *testing.T, app fyne.App) *PreferencesSynchronizer {
+	t.Helper()
+
+	return newStorageSynchronizer(t, app.Preferences())
+}
+
+// Synchronizer of given storage with default migrations, writes of its
+// listeners are waited for when the test ends, so they do not run
+// while the next test does
+func newStorageSynchronizer(t *testing.T, storage Storage) *PreferencesSynchronizer {
+	t.Helper()
+
+	prefSync, err := NewPreferencesSynchronizerWithStorage(storage, DefaultMigrations)
+	if err != nil {
+		t.Fatalf("NewPreferencesSynchronizerWithStorage should not return an error: %v", err)
+	}
+
+	t.Cleanup(func() { waitForListeners(t) })
+
+	return prefSync
+}
+
 func TestPreferences_Bool_Empty(t *testing.T) {
 	assert := assert{t}
 	testApp := test.NewApp()
@@ -269,3 +299,580 @@ func TestPreferences_IntArray_NonEmpty(t *testing.T) {
 	assert.Equal([]int{4, 5, 6}, valueIntArray, "Value should be [4, 5, 6]")
 
 }
+
+// Value binding of types fyne has no binding for
+func newValue[T any]() *xbinding.Value[T] {
+	value := xbinding.NewValue[T]()
+	return &value
+}
+
+// Current value of the binding, it is never failing in these tests
+func valueOf[T any](value *xbinding.Value[T]) T {
+	v, _ := value.Get()
+	return v
+}
+
+// Bindable value which fails to read or write once errors are set
+// Listeners are kept apart and called by hand, so they are not called
+// by the binding while errors are changed
+type failingValue[T any] struct {
+	xbinding.Value[T]
+	listeners []binding.DataListener
+	getErr    error
+	setErr    error
+}
+
+func (v *failingValue[T]) Get() (T, error) {
+	if v.getErr != nil {
+		var zero T
+		return zero, v.getErr
+	}
+
+	return v.Value.Get()
+}
+
+func (v *failingValue[T]) Set(value T) error {
+	if v.setErr != nil {
+		return v.setErr
+	}
+
+	return v.Value.Set(value)
+}
+
+func (v *failingValue[T]) AddListener(l binding.DataListener) {
+	v.listeners = append(v.listeners, l)
+	l.DataChanged()
+}
+
+func (v *failingValue[T]) RemoveListener(l binding.DataListener) {
+	for i, listener := range v.listeners {
+		if listener == l {
+			v.listeners = append(v.listeners[:i], v.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+func TestPreferences_Generic_Codecs(t *testing.T) {
+	assert := assert{t}
+	testApp := test.NewApp()
+
+	type window struct {
+		Width  int
+		Height int
+	}
+
+	prefSync := newTestSynchronizer(t, testApp)
+
+	floatValue := newValue[float64]()
+	assert.NoError(Add[float64](prefSync, Preference[float64]{
+		Key: "float", Value: floatValue, Fallback: 1.5, Codec: FloatCodec{},
+	}), "Add float should not return an error")
+	assert.Equal(1.5, valueOf(floatValue), "Float should be fallback")
+
+	durationValue := newValue[time.Duration]()
+	assert.NoError(Add[time.Duration](prefSync, Preference[time.Duration]{
+		Key: "duration", Value: durationValue, Fallback: time.Minute, Codec: DurationCodec{},
+	}), "Add duration should not return an error")
+	assert.Equal(time.Minute, valueOf(durationValue), "Duration should be fallback")
+
+	timeValue := newValue[time.Time]()
+	fallbackTime := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(Add[time.Time](prefSync, Preference[time.Time]{
+		Key: "time", Value: timeValue, Fallback: fallbackTime, Codec: TimeCodec{},
+	}), "Add time should not return an error")
+	assert.True(fallbackTime.Equal(valueOf(timeValue)), "Time should be fallback")
+
+	listValue := newValue[[]string]()
+	assert.NoError(Add[[]string](prefSync, Preference[[]string]{
+		Key: "list", Value: listValue, Fallback: []string{"a"}, Codec: StringListCodec{},
+	}), "Add string list should not return an error")
+	assert.Equal([]string{"a"}, valueOf(listValue), "String list should be fallback")
+
+	mapValue := newValue[map[string]int]()
+	assert.NoError(Add[map[string]int](prefSync, Preference[map[string]int]{
+		Key: "map", Value: mapValue, Fallback: map[string]int{"a": 1}, Codec: MapCodec[int]{},
+	}), "Add map should not return an error")
+	assert.Equal(map[string]int{"a": 1}, valueOf(mapValue), "Map should be fallback")
+
+	structValue := newValue[window]()
+	assert.NoError(Add[window](prefSync, Preference[window]{
+		Key: "struct", Value: structValue, Fallback: window{600, 400}, Codec: JSONCodec[window]{},
+	}), "Add struct should not return an error")
+	assert.Equal(window{600, 400}, valueOf(structValue), "Struct should be fallback")
+
+	floatValue.Set(2.5)
+	durationValue.Set(90 * time.Minute)
+	timeValue.Set(fallbackTime.Add(time.Hour))
+	listValue.Set([]string{"b", "c"})
+	mapValue.Set(map[string]int{"b": 2})
+	structValue.Set(window{800, 600})
+	waitForListeners(t)
+
+	// new synchronizer on the same app reads what was stored
+	reloaded := newTestSynchronizer(t, testApp)
+
+	reloadedFloat := newValue[float64]()
+	assert.NoError(Add[float64](reloaded, Preference[float64]{
+		Key: "float", Value: reloadedFloat, Fallback: 1.5, Codec: FloatCodec{},
+	}), "Add float should not return an error")
+	assert.Equal(2.5, valueOf(reloadedFloat), "Float should be stored value")
+
+	reloadedDuration := newValue[time.Duration]()
+	assert.NoError(Add[time.Duration](reloaded, Preference[time.Duration]{
+		Key: "duration", Value: reloadedDuration, Fallback: time.Minute, Codec: DurationCodec{},
+	}), "Add duration should not return an error")
+	assert.Equal(90*time.Minute, valueOf(reloadedDuration), "Duration should be stored value")
+
+	reloadedTime := newValue[time.Time]()
+	assert.NoError(Add[time.Time](reloaded, Preference[time.Time]{
+		Key: "time", Value: reloadedTime, Fallback: fallbackTime, Codec: TimeCodec{},
+	}), "Add time should not return an error")
+	assert.True(fallbackTime.Add(time.Hour).Equal(valueOf(reloadedTime)), "Time should be stored value")
+
+	reloadedList := newValue[[]string]()
+	assert.NoError(Add[[]string](reloaded, Preference[[]string]{
+		Key: "list", Value: reloadedList, Fallback: []string{"a"}, Codec: StringListCodec{},
+	}), "Add string list should not return an error")
+	assert.Equal([]string{"b", "c"}, valueOf(reloadedList), "String list should be stored value")
+
+	reloadedMap := newValue[map[string]int]()
+	assert.NoError(Add[map[string]int](reloaded, Preference[map[string]int]{
+		Key: "map", Value: reloadedMap, Fallback: map[string]int{"a": 1}, Codec: MapCodec[int]{},
+	}), "Add map should not return an error")
+	assert.Equal(map[string]int{"b": 2}, valueOf(reloadedMap), "Map should be stored value")
+
+	reloadedStruct := newValue[window]()
+	assert.NoError(Add[window](reloaded, Preference[window]{
+		Key: "struct", Value: reloadedStruct, Fallback: window{600, 400}, Codec: JSONCodec[window]{},
+	}), "Add struct should not return an error")
+	assert.Equal(window{800, 600}, valueOf(reloadedStruct), "Struct should be stored value")
+}
+
+func TestPreferences_Generic_KeyUniqueAcrossTypes(t *testing.T) {
+	assert := assert{t}
+	testApp := test.NewApp()
+
+	prefSync := newTestSynchronizer(t, testApp)
+
+	err := prefSync.AddString(StringPreference{
+		Key:      "shared",
+		Value:    binding.NewString(),
+		Fallback: "",
+	})
+	assert.NoError(err, "AddString should not return an error")
+
+	err = Add[float64](prefSync, Preference[float64]{
+		Key: "shared", Value: newValue[float64](), Codec: FloatCodec{},
+	})
+	assert.Error(err, "Add should return an error for key used by other type")
+
+	err = Add[float64](prefSync, Preference[float64]{
+		Key: "noCodec", Value: newValue[float64](),
+	})
+	assert.Error(err, "Add should return an error without codec")
+
+	assert.Equal([]string{"shared"}, prefSync.Keys(), "Only first preference should be registered")
+}
+
+func TestPreferences_CorruptedValue(t *testing.T) {
+	assert := assert{t}
+	testApp := test.NewApp()
+	testApp.Preferences().SetString("testIntArray", "{not json")
+
+	prefSync := newTestSynchronizer(t, testApp)
+
+	testIntArrayBinding := xbinding.NewIntArray()
+
+	err := prefSync.AddIntArray(IntArrayPreference{
+		Key:      "testIntArray",
+		Value:    testIntArrayBinding,
+		Fallback: []int{1, 2, 3},
+	})
+
+	assert.NoError(err, "AddIntArray should recover from corrupted value")
+
+	valueIntArray, err := testIntArrayBinding.Get()
+	assert.NoError(err, "Get should not return an error")
+	assert.Equal([]int{1, 2, 3}, valueIntArray, "Value should be fallback")
+
+	// listener stores the fallback in place of removed value
+	eventually(t, func() bool {
+		return testApp.Preferences().String("testIntArray") == "[1,2,3]"
+	}, "Corrupted value should be replaced with fallback")
+}
+
+func TestPreferences_ResetAndRemove(t *testing.T) {
+	assert := assert{t}
+	testApp := test.NewApp()
+
+	prefSync := newTestSynchronizer(t, testApp)
+
+	stringValue := newValue[string]()
+	intValue := newValue[int]()
+
+	assert.NoError(Add[string](prefSync, Preference[string]{
+		Key: "string", Value: stringValue, Fallback: "fallback", Codec: StringCodec{},
+	}), "Add string should not return an error")
+	assert.NoError(Add[int](prefSync, Preference[int]{
+		Key: "int", Value: intValue, Fallback: 7, Codec: IntCodec{},
+	}), "Add int should not return an error")
+
+	stringValue.Set("changed")
+	intValue.Set(8)
+
+	assert.NoError(prefSync.Reset("string"), "Reset should not return an error")
+	waitForListeners(t)
+	assert.Equal("fallback", valueOf(stringValue), "Value should be fallback")
+	assert.Equal("fallback", testApp.Preferences().String("string"), "Fallback should be stored")
+	assert.Equal(8, valueOf(intValue), "Other value should not change")
+
+	intValue.Set(9)
+	stringValue.Set("changed")
+
+	assert.NoError(prefSync.ResetKeys([]string{"int"}), "ResetKeys should not return an error")
+	assert.Equal(7, valueOf(intValue), "Value should be fallback")
+	assert.Equal("changed", valueOf(stringValue), "Value of other key should not change")
+
+	intValue.Set(9)
+
+	assert.Error(prefSync.ResetKeys([]string{"int", "unknown"}), "ResetKeys with unknown key should return an error")
+	assert.Equal(9, valueOf(intValue), "Nothing should be reset when a key is unknown")
+
+	assert.NoError(prefSync.ResetAll(), "ResetAll should not return an error")
+	assert.Equal("fallback", valueOf(stringValue), "Value should be fallback")
+	assert.Equal(7, valueOf(intValue), "Value should be fallback")
+
+	assert.Error(prefSync.Reset("unknown"), "Reset of unknown key should return an error")
+
+	// values stored by listeners called after removal would stay stored
+	waitForListeners(t)
+	assert.NoError(prefSync.Remove("int"), "Remove should not return an error")
+	assert.Equal([]string{"string"}, prefSync.Keys(), "Removed key should not be registered")
+	assert.Equal(10, testApp.Preferences().IntWithFallback("int", 10), "Value should not be stored")
+
+	intValue.Set(11)
+	waitForListeners(t)
+	assert.Equal(10, testApp.Preferences().IntWithFallback("int", 10), "Removed preference should not be stored")
+
+	assert.Error(prefSync.Remove("int"), "Remove of removed key should return an error")
+	assert.NoError(Add[int](prefSync, Preference[int]{
+		Key: "int", Value: intValue, Fallback: 7, Codec: IntCodec{},
+	}), "Removed key should be free again")
+}
+
+// Counts writes, to check they are coalesced
+type countingStorage struct {
+	*MemoryStorage
+	writes int32
+}
+
+func (c *countingStorage) SetString(key string, value string) {
+	atomic.AddInt32(&c.writes, 1)
+	c.MemoryStorage.SetString(key, value)
+}
+
+func TestPreferences_Debounce_Flush(t *testing.T) {
+	assert := assert{t}
+	prefs := &countingStorage{MemoryStorage: NewMemoryStorage()}
+
+	prefSync := newStorageSynchronizer(t, prefs)
+	prefSync.SetDebounce(time.Hour)
+
+	value := newValue[string]()
+	assert.NoError(Add[string](prefSync, Preference[string]{
+		Key: "string", Value: value, Fallback: "fallback", Codec: StringCodec{},
+	}), "Add should not return an error")
+
+	for i := 0; i < 100; i++ {
+		value.Set(fmt.Sprintf("value %d", i))
+	}
+	waitForListeners(t)
+
+	assert.Equal("", prefs.String("string"), "Nothing should be stored before debounce")
+
+	assert.NoError(prefSync.Flush(), "Flush should not return an error")
+	assert.Equal("value 99", prefs.String("string"), "Last value should be stored")
+	assert.Equal(int32(1), atomic.LoadInt32(&prefs.writes), "Writes should be coalesced into one")
+
+	assert.NoError(prefSync.Flush(), "Second Flush should not return an error")
+	assert.Equal(int32(1), atomic.LoadInt32(&prefs.writes), "Second Flush should not write again")
+}
+
+func TestPreferences_Debounce_FlushWhileHeld(t *testing.T) {
+	assert := assert{t}
+	prefs := NewMemoryStorage()
+
+	prefSync := newStorageSynchronizer(t, prefs)
+	prefSync.SetDebounce(time.Hour)
+
+	value := newValue[string]()
+	assert.NoError(Add[string](prefSync, Preference[string]{
+		Key: "string", Value: value, Fallback: "fallback", Codec: StringCodec{},
+	}), "Add should not return an error")
+
+	prefSync.holdWrites()
+	value.Set("held")
+	waitForListeners(t)
+
+	// e.g. debounce timer firing while a batch is applied
+	assert.NoError(prefSync.Flush(), "Flush should not return an error")
+	assert.Equal("", prefs.String("string"), "Held writes should not be stored by Flush")
+
+	prefSync.SetDebounce(0)
+	assert.NoError(prefSync.releaseWrites(), "releaseWrites should not return an error")
+	assert.Equal("held", prefs.String("string"), "Held writes should be stored when released")
+}
+
+func TestPreferences_Debounce_Timer(t *testing.T) {
+	assert := assert{t}
+	testApp := test.NewApp()
+
+	prefSync := newTestSynchronizer(t, testApp)
+	prefSync.SetDebounce(10 * time.Millisecond)
+
+	stringValue := newValue[string]()
+	intValue := newValue[int]()
+
+	assert.NoError(Add[string](prefSync, Preference[string]{
+		Key: "string", Value: stringValue, Fallback: "fallback", Codec: StringCodec{},
+	}), "Add string should not return an error")
+	assert.NoError(Add[int](prefSync, Preference[int]{
+		Key: "int", Value: intValue, Fallback: 0, Codec: IntCodec{},
+	}), "Add int should not return an error")
+
+	for i := 1; i <= 50; i++ {
+		stringValue.Set(fmt.Sprintf("value %d", i))
+		intValue.Set(i)
+	}
+
+	eventually(t, func() bool {
+		return testApp.Preferences().String("string") == "value 50" &&
+			testApp.Preferences().Int("int") == 50
+	}, "Final values should be stored after debounce")
+}
+
+func TestPreferences_ExternalChange(t *testing.T) {
+	assert := assert{t}
+	prefs := &countingStorage{MemoryStorage: NewMemoryStorage()}
+
+	prefSync := newStorageSynchronizer(t, prefs)
+
+	value := binding.NewString()
+	assert.NoError(prefSync.AddString(StringPreference{
+		Key: "string", Value: value, Fallback: "fallback",
+	}), "AddString should not return an error")
+
+	eventually(t, func() bool {
+		return prefs.String("string") == "fallback"
+	}, "Fallback should be stored")
+
+	// written by someone else, bypassing the synchronizer
+	prefs.MemoryStorage.SetString("string", "external")
+
+	eventually(t, func() bool {
+		v, _ := value.Get()
+		return v == "external"
+	}, "External change should reach the binding")
+
+	writes := atomic.LoadInt32(&prefs.writes)
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal("external", prefs.String("string"), "Stored value should stay the external one")
+	assert.True(atomic.LoadInt32(&prefs.writes)-writes <= 1, "Value should not go back and forth between binding and storage")
+
+	value.Set("own")
+	eventually(t, func() bool {
+		return prefs.String("string") == "own"
+	}, "Own change should still be stored")
+
+	prefs.MemoryStorage.RemoveValue("string")
+	eventually(t, func() bool {
+		v, _ := value.Get()
+		return v == "fallback"
+	}, "Externally removed value should set binding to fallback")
+}
+
+func TestPreferences_ExternalChange_PendingWins(t *testing.T) {
+	assert := assert{t}
+	prefs := NewMemoryStorage()
+
+	prefSync := newStorageSynchronizer(t, prefs)
+
+	value := newValue[string]()
+	assert.NoError(Add[string](prefSync, Preference[string]{
+		Key: "string", Value: value, Fallback: "fallback", Codec: StringCodec{},
+	}), "Add should not return an error")
+
+	prefSync.SetDebounce(time.Hour)
+	value.Set("own")
+	waitForListeners(t)
+
+	prefs.SetString("string", "external")
+
+	v, _ := value.Get()
+	assert.Equal("own", v, "Change waiting for debounce should not be overwritten")
+
+	assert.NoError(prefSync.Flush(), "Flush should not return an error")
+	assert.Equal("own", prefs.String("string"), "Own change should be stored")
+}
+
+func TestISODuration(t *testing.T) {
+	tests := []struct {
+		text     string
+		duration time.Duration
+		// form FormatISODuration gives, empty if the same as text
+		formatted string
+		wantErr   bool
+	}{
+		{text: "PT0S", duration: 0},
+		{text: "PT1H30M", duration: 90 * time.Minute},
+		{text: "PT36H", duration: 36 * time.Hour},
+		{text: "P1DT12H", duration: 36 * time.Hour, formatted: "PT36H"},
+		{text: "P2W", duration: 14 * 24 * time.Hour, formatted: "PT336H"},
+		{text: "PT1.5S", duration: 1500 * time.Millisecond},
+		{text: "PT0,25S", duration: 250 * time.Millisecond, formatted: "PT0.25S"},
+		{text: "PT0.000000001S", duration: time.Nanosecond},
+		{text: "-PT5M", duration: -5 * time.Minute},
+		{text: "PT1H0M5S", duration: time.Hour + 5*time.Second, formatted: "PT1H5S"},
+		{text: "PT2562047H47M16.854775807S", duration: math.MaxInt64},
+		{text: "-PT2562047H47M16.854775808S", duration: math.MinInt64},
+		{text: "-PT2562047H47M16.854775807S", duration: -math.MaxInt64},
+		{text: "PT2562047H47M16.854775808S", wantErr: true},
+		{text: "-PT2562047H47M16.854775809S", wantErr: true},
+		{text: "PT2562048H", wantErr: true},
+		{text: "P15251W", wantErr: true},
+		{text: "P1000000000000000000000D", wantErr: true},
+		{text: "P", wantErr: true},
+		{text: "PT", wantErr: true},
+		{text: "P1DT", wantErr: true},
+		{text: "P1Y", wantErr: true},
+		{text: "P1M", wantErr: true},
+		{text: "1h30m", wantErr: true},
+		{text: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseISODuration(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseISODuration(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got != tt.duration {
+				t.Errorf("ParseISODuration(%q) = %v, want %v", tt.text, got, tt.duration)
+			}
+
+			formatted := tt.formatted
+			if formatted == "" {
+				formatted = tt.text
+			}
+
+			if FormatISODuration(tt.duration) != formatted {
+				t.Errorf("FormatISODuration(%v) = %q, want %q", tt.duration, FormatISODuration(tt.duration), formatted)
+			}
+		})
+	}
+}
+
+func TestPreferences_DurationAndLocation(t *testing.T) {
+	assert := assert{t}
+	storage := NewMemoryStorage()
+
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	assert.NoError(err, "LoadLocation should not return an error")
+
+	prefSync := newStorageSynchronizer(t, storage)
+
+	duration := xbinding.NewDuration()
+	assert.NoError(prefSync.AddDuration(DurationPreference{
+		Key: "countdown", Value: duration, Fallback: 5 * time.Minute,
+	}), "AddDuration should not return an error")
+
+	location := xbinding.NewLocation()
+	assert.NoError(prefSync.AddLocation(LocationPreference{
+		Key: "referenceZone", Value: location, Fallback: time.UTC,
+	}), "AddLocation should not return an error")
+
+	assert.Error(prefSync.AddLocation(LocationPreference{
+		Key: "nilZone", Value: xbinding.NewLocation(),
+	}), "Nil fallback location should be rejected")
+
+	duration.Set(90 * time.Minute)
+	location.Set(warsaw)
+
+	eventually(t, func() bool {
+		return storage.String("countdown") == "PT1H30M" && storage.String("referenceZone") == "Europe/Warsaw"
+	}, "Duration and location should be stored as ISO 8601 and IANA name")
+
+	data, err := prefSync.Export()
+	assert.NoError(err, "Export should not return an error")
+	assert.True(strings.Contains(string(data), `"PT1H30M"`), "Duration should be exported as ISO 8601")
+	assert.True(strings.Contains(string(data), `"Europe/Warsaw"`), "Location should be exported as IANA name")
+
+	// the same settings in a fresh synchronizer
+	other := newStorageSynchronizer(t, NewMemoryStorage())
+	otherDuration := xbinding.NewDuration()
+	otherLocation := xbinding.NewLocation()
+	assert.NoError(other.AddDuration(DurationPreference{Key: "countdown", Value: otherDuration}), "AddDuration should not return an error")
+	assert.NoError(other.AddLocation(LocationPreference{Key: "referenceZone", Value: otherLocation, Fallback: time.UTC}), "AddLocation should not return an error")
+
+	plan, err := other.PrepareImport(data)
+	assert.NoError(err, "PrepareImport should not return an error")
+	assert.NoError(plan.Apply(), "Apply should not return an error")
+
+	gotDuration, _ := otherDuration.Get()
+	gotLocation, _ := otherLocation.Get()
+	assert.Equal(90*time.Minute, gotDuration, "Duration should be imported")
+	assert.Equal("Europe/Warsaw", gotLocation.String(), "Location should be imported")
+}
+
+func TestPreferences_FailingBinding(t *testing.T) {
+	assert := assert{t}
+	storage := NewMemoryStorage()
+
+	prefSync := newStorageSynchronizer(t, storage)
+
+	collector := &errorCollector{}
+	prefSync.SetErrorHandler(collector.handle)
+
+	value := &failingValue[string]{Value: xbinding.NewValue[string]()}
+	assert.NoError(Add[string](prefSync, Preference[string]{
+		Key:       "string",
+		Value:     value,
+		Fallback:  "fallback",
+		Codec:     StringCodec{},
+		Normalize: strings.ToLower,
+	}), "Add should not return an error")
+	assert.Equal(0, collector.count(), "Nothing should be reported before binding fails")
+
+	brokenErr := fmt.Errorf("broken binding")
+
+	// listeners are called by hand, as failing value cannot be set
+	notify := func() {
+		for _, l := range value.listeners {
+			l.DataChanged()
+		}
+	}
+
+	value.getErr = brokenErr
+	notify()
+
+	assert.Equal(1, collector.count(), "Failed read should be reported")
+	assert.True(errors.Is(collector.errors[0], brokenErr), "Reported error should wrap the binding error")
+	assert.True(strings.Contains(collector.errors[0].Error(), "string"), "Reported error should name the key")
+	assert.Equal("fallback", storage.String("string"), "Stored value should not change")
+
+	// normalized value cannot be written back
+	value.getErr = nil
+	value.setErr = brokenErr
+	value.Value.Set("UPPER")
+	notify()
+
+	assert.Equal(2, collector.count(), "Failed correction should be reported")
+	assert.True(errors.Is(collector.errors[1], brokenErr), "Reported error should wrap the binding error")
+	assert.Equal("fallback", storage.String("string"), "Value which was not corrected should not be stored")
+}
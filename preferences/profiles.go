@@ -0,0 +1,345 @@
+package preferences
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"fyne.io/fyne/v2/data/binding"
+	"github.com/sharki13/timestamp-converter/xbinding"
+)
+
+// Keys under which profiles are stored
+const (
+	ProfilesKey      = "profiles"
+	ActiveProfileKey = "activeProfile"
+)
+
+// Name of profile created from current settings on first run
+const DefaultProfileName = "Default"
+
+// Values of preferences as JSON, by key
+type Snapshot map[string]json.RawMessage
+
+// Returns values of given registered preferences
+func (p *PreferencesSynchronizer) Snapshot(keys []string) (Snapshot, error) {
+	snapshot := make(Snapshot)
+
+	for _, key := range keys {
+		pref, ok := p.registry[key]
+		if !ok {
+			return nil, fmt.Errorf("key %s is not registered", key)
+		}
+
+		raw, err := pref.exportJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot[key] = raw
+	}
+
+	return snapshot, nil
+}
+
+// Sets all values from snapshot, either all of them or none,
+// every value is decoded before the first one is set, and values set
+// before a binding fails to set its one are set back
+// Values are stored together once all of them are set
+func (p *PreferencesSynchronizer) ApplySnapshot(snapshot Snapshot) error {
+	keys := make([]string, 0, len(snapshot))
+	for key := range snapshot {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	previous, err := p.Snapshot(keys)
+	if err != nil {
+		return err
+	}
+
+	applies := make([]func() error, 0, len(keys))
+	restores := make([]func() error, 0, len(keys))
+
+	for _, key := range keys {
+		pref := p.registry[key]
+
+		apply, _, err := pref.importJSON(snapshot[key])
+		if err != nil {
+			return fmt.Errorf("invalid value of %s: %w", key, err)
+		}
+
+		restore, _, err := pref.importJSON(previous[key])
+		if err != nil {
+			return fmt.Errorf("invalid current value of %s: %w", key, err)
+		}
+
+		applies = append(applies, apply)
+		restores = append(restores, restore)
+	}
+
+	p.holdWrites()
+
+	for i, apply := range applies {
+		if err := apply(); err != nil {
+			for _, restore := range restores[:i] {
+				restore()
+			}
+
+			p.releaseWrites()
+			return err
+		}
+	}
+
+	// listeners of bindings would store values one by one later
+	for _, key := range keys {
+		p.listeners[key].DataChanged()
+	}
+
+	return p.releaseWrites()
+}
+
+// Named sets of values of chosen preferences, e.g. visible timezones and format,
+// values of the active profile follow changes of the bindings
+type Profiles struct {
+	synchronizer *PreferencesSynchronizer
+	keys         []string
+	profiles     *xbinding.Value[map[string]Snapshot]
+	active       binding.String
+
+	// held while profile is switched, so values of two profiles
+	// are not saved together into one of them
+	lock sync.Mutex
+}
+
+// Creates profiles of given keys, they have to be registered already
+// Profiles are stored through the synchronizer too, on the first run
+// DefaultProfileName profile is created from current values
+func NewProfiles(p *PreferencesSynchronizer, keys []string) (*Profiles, error) {
+	for _, key := range keys {
+		if !p.isKeyExisting(key) {
+			return nil, fmt.Errorf("key %s is not registered", key)
+		}
+	}
+
+	stored := xbinding.NewValue[map[string]Snapshot]()
+	pr := &Profiles{
+		synchronizer: p,
+		keys:         keys,
+		profiles:     &stored,
+		active:       binding.NewString(),
+	}
+
+	err := Add[map[string]Snapshot](p, Preference[map[string]Snapshot]{
+		Key:      ProfilesKey,
+		Value:    pr.profiles,
+		Fallback: map[string]Snapshot{},
+		Codec:    JSONCodec[map[string]Snapshot]{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.AddString(StringPreference{
+		Key:      ActiveProfileKey,
+		Value:    pr.active,
+		Fallback: DefaultProfileName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, _ := pr.profiles.Get()
+	active, _ := pr.active.Get()
+
+	if _, ok := profiles[active]; !ok {
+		if err := pr.saveActive(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range keys {
+		p.registry[key].addListener(binding.NewDataListener(func() {
+			if err := pr.saveActive(); err != nil {
+				p.reportError(err)
+			}
+		}))
+	}
+
+	return pr, nil
+}
+
+// Stores current values into the active profile, unless they are there already
+func (pr *Profiles) saveActive() error {
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+
+	return pr.save()
+}
+
+func (pr *Profiles) save() error {
+	snapshot, err := pr.synchronizer.Snapshot(pr.keys)
+	if err != nil {
+		return err
+	}
+
+	active, err := pr.active.Get()
+	if err != nil {
+		return err
+	}
+
+	profiles, _ := pr.profiles.Get()
+	if saved, ok := profiles[active]; ok && reflect.DeepEqual(saved, snapshot) {
+		return nil
+	}
+
+	return pr.update(func(profiles map[string]Snapshot) error {
+		profiles[active] = snapshot
+		return nil
+	})
+}
+
+// Changes copy of profiles map and stores it
+func (pr *Profiles) update(change func(profiles map[string]Snapshot) error) error {
+	current, err := pr.profiles.Get()
+	if err != nil {
+		return err
+	}
+
+	profiles := make(map[string]Snapshot, len(current))
+	for name, snapshot := range current {
+		profiles[name] = snapshot
+	}
+
+	if err := change(profiles); err != nil {
+		return err
+	}
+
+	return pr.profiles.Set(profiles)
+}
+
+// Returns names of all profiles, sorted
+func (pr *Profiles) Names() []string {
+	profiles, _ := pr.profiles.Get()
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Returns name of the active profile
+func (pr *Profiles) Active() string {
+	active, _ := pr.active.Get()
+	return active
+}
+
+// Listener is called when profiles are added, removed or switched
+func (pr *Profiles) AddListener(listener binding.DataListener) {
+	pr.profiles.AddListener(listener)
+	pr.active.AddListener(listener)
+}
+
+// Makes given profile active and applies all its values at once,
+// listeners saving the active profile wait until all of them are set
+func (pr *Profiles) Switch(name string) error {
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+
+	profiles, _ := pr.profiles.Get()
+
+	snapshot, ok := profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %s does not exist", name)
+	}
+
+	// listeners are called from a queue, so they may not have saved
+	// the latest values yet
+	if err := pr.save(); err != nil {
+		return err
+	}
+
+	active, err := json.Marshal(name)
+	if err != nil {
+		return err
+	}
+
+	// active profile is switched with its values
+	batch := Snapshot{ActiveProfileKey: active}
+	for key, value := range snapshot {
+		batch[key] = value
+	}
+
+	if err := pr.synchronizer.ApplySnapshot(batch); err != nil {
+		return err
+	}
+
+	// values may have been normalized while applied
+	return pr.save()
+}
+
+// Creates profile with fallback values of all preferences
+func (pr *Profiles) Create(name string) error {
+	snapshot := make(Snapshot)
+
+	for _, key := range pr.keys {
+		raw, err := pr.synchronizer.registry[key].fallbackJSON()
+		if err != nil {
+			return err
+		}
+
+		snapshot[key] = raw
+	}
+
+	return pr.add(name, snapshot)
+}
+
+// Creates profile with the same values as the existing one
+func (pr *Profiles) Clone(from string, name string) error {
+	profiles, _ := pr.profiles.Get()
+
+	snapshot, ok := profiles[from]
+	if !ok {
+		return fmt.Errorf("profile %s does not exist", from)
+	}
+
+	return pr.add(name, snapshot)
+}
+
+func (pr *Profiles) add(name string, snapshot Snapshot) error {
+	if name == "" {
+		return fmt.Errorf("profile name cannot be empty")
+	}
+
+	return pr.update(func(profiles map[string]Snapshot) error {
+		if _, ok := profiles[name]; ok {
+			return fmt.Errorf("profile %s already exists", name)
+		}
+
+		profiles[name] = snapshot
+		return nil
+	})
+}
+
+// Deletes profile, the active one cannot be deleted
+func (pr *Profiles) Delete(name string) error {
+	if name == pr.Active() {
+		return fmt.Errorf("active profile %s cannot be deleted", name)
+	}
+
+	return pr.update(func(profiles map[string]Snapshot) error {
+		if _, ok := profiles[name]; !ok {
+			return fmt.Errorf("profile %s does not exist", name)
+		}
+
+		delete(profiles, name)
+		return nil
+	})
+}
@@ -0,0 +1,195 @@
+package preferences
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"github.com/sharki13/timestamp-converter/xbinding"
+)
+
+// Waits for fyne binding listeners, they are called from a queue
+func eventually(t *testing.T, condition func() bool, message string) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Error(message)
+}
+
+// Fyne calls listeners one by one in the order they were queued, so once
+// a listener added now is called, the ones queued before it were called too
+// Listeners queue further ones, e.g. profiles saved when a value changes,
+// so it is repeated a few times
+func waitForListeners(t *testing.T) {
+	t.Helper()
+
+	for i := 0; i < 3; i++ {
+		called := make(chan struct{}, 1)
+		binding.NewBool().AddListener(binding.NewDataListener(func() { called <- struct{}{} }))
+
+		select {
+		case <-called:
+		case <-time.After(time.Second):
+			t.Fatal("listeners were not called")
+		}
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	assert := assert{t}
+	testApp := test.NewApp()
+
+	prefSync := newTestSynchronizer(t, testApp)
+	format := binding.NewString()
+	timezones := xbinding.NewIntArray()
+
+	assert.NoError(prefSync.AddString(StringPreference{
+		Key:      "format",
+		Value:    format,
+		Fallback: "2006",
+	}), "AddString should not return an error")
+
+	assert.NoError(prefSync.AddIntArray(IntArrayPreference{
+		Key:      "visibleTimezones",
+		Value:    timezones,
+		Fallback: []int{0},
+	}), "AddIntArray should not return an error")
+
+	profiles, err := NewProfiles(prefSync, []string{"format", "visibleTimezones"})
+	assert.NoError(err, "NewProfiles should not return an error")
+	assert.Equal([]string{DefaultProfileName}, profiles.Names(), "Default profile should be created")
+	assert.Equal(DefaultProfileName, profiles.Active(), "Default profile should be active")
+
+	format.Set("15:04")
+	timezones.Set([]int{0, 5})
+
+	assert.NoError(profiles.Clone(DefaultProfileName, "US on-call"), "Clone should not return an error")
+	assert.NoError(profiles.Create("APAC"), "Create should not return an error")
+	assert.Error(profiles.Create("APAC"), "Create should return an error for existing name")
+	assert.Error(profiles.Create(""), "Create should return an error for empty name")
+	assert.Equal([]string{"APAC", DefaultProfileName, "US on-call"}, profiles.Names(), "All profiles should be listed")
+
+	assert.NoError(profiles.Switch("APAC"), "Switch should not return an error")
+	value, _ := format.Get()
+	assert.Equal("2006", value, "New profile should have fallback format")
+	ids, _ := timezones.Get()
+	assert.Equal([]int{0}, ids, "New profile should have fallback timezones")
+
+	format.Set("Jan 2")
+
+	assert.NoError(profiles.Switch(DefaultProfileName), "Switch should not return an error")
+	value, _ = format.Get()
+	assert.Equal("15:04", value, "Default profile should keep its format")
+	ids, _ = timezones.Get()
+	assert.Equal([]int{0, 5}, ids, "Default profile should keep its timezones")
+
+	eventually(t, func() bool {
+		return testApp.Preferences().String("format") == "15:04"
+	}, "Switched values should be stored")
+
+	assert.NoError(profiles.Switch("APAC"), "Switch should not return an error")
+	eventually(t, func() bool {
+		value, _ := format.Get()
+		return value == "Jan 2"
+	}, "Changes of profile should be kept")
+
+	assert.Error(profiles.Delete("APAC"), "Active profile cannot be deleted")
+	assert.NoError(profiles.Delete("US on-call"), "Delete should not return an error")
+	assert.Error(profiles.Switch("US on-call"), "Deleted profile cannot be switched to")
+
+	// profiles are restored on the next run
+	waitForListeners(t)
+	assert.Equal("APAC", testApp.Preferences().String(ActiveProfileKey), "Active profile should be stored")
+
+	reloaded := newTestSynchronizer(t, testApp)
+	assert.NoError(reloaded.AddString(StringPreference{Key: "format", Value: binding.NewString()}), "AddString should not return an error")
+	assert.NoError(reloaded.AddIntArray(IntArrayPreference{Key: "visibleTimezones", Value: xbinding.NewIntArray()}), "AddIntArray should not return an error")
+
+	reloadedProfiles, err := NewProfiles(reloaded, []string{"format", "visibleTimezones"})
+	assert.NoError(err, "NewProfiles should not return an error")
+	assert.Equal([]string{"APAC", DefaultProfileName}, reloadedProfiles.Names(), "Profiles should be restored")
+	assert.Equal("APAC", reloadedProfiles.Active(), "Active profile should be restored")
+}
+
+func TestProfiles_SwitchAtOnce(t *testing.T) {
+	assert := assert{t}
+	storage := NewMemoryStorage()
+
+	prefSync := newStorageSynchronizer(t, storage)
+
+	format := binding.NewString()
+	timezones := xbinding.NewIntArray()
+
+	assert.NoError(prefSync.AddString(StringPreference{Key: "format", Value: format, Fallback: "2006"}), "AddString should not return an error")
+	assert.NoError(prefSync.AddIntArray(IntArrayPreference{Key: "visibleTimezones", Value: timezones, Fallback: []int{0, 1}}), "AddIntArray should not return an error")
+
+	profiles, err := NewProfiles(prefSync, []string{"format", "visibleTimezones"})
+	assert.NoError(err, "NewProfiles should not return an error")
+	assert.NoError(profiles.Create("APAC"), "Create should not return an error")
+	assert.NoError(profiles.Switch("APAC"), "Switch should not return an error")
+
+	format.Set("15:04")
+	timezones.Set([]int{1, 0})
+	eventually(t, func() bool {
+		return storage.String("visibleTimezones") == "[1,0]"
+	}, "Changed values should be stored")
+
+	var saved atomic.Int32
+	profiles.profiles.AddListener(binding.NewDataListener(func() {
+		saved.Add(1)
+	}))
+	waitForListeners(t)
+	saved.Store(0)
+
+	assert.NoError(profiles.Switch(DefaultProfileName), "Switch should not return an error")
+
+	// stored by Switch itself, not later by listeners
+	assert.Equal("2006", storage.String("format"), "Format should be stored at once")
+	assert.Equal("[0,1]", storage.String("visibleTimezones"), "Timezones should be stored at once")
+	assert.Equal(DefaultProfileName, storage.String(ActiveProfileKey), "Active profile should be stored at once")
+	assert.Equal(int32(0), saved.Load(), "Unchanged profiles should not be saved")
+
+	waitForListeners(t)
+	assert.Equal(int32(0), saved.Load(), "Listeners should not save profiles again")
+
+	apac, _ := profiles.profiles.Get()
+	assert.Equal(`"15:04"`, string(apac["APAC"]["format"]), "Previous profile should keep its format")
+	assert.Equal(`[1,0]`, string(apac["APAC"]["visibleTimezones"]), "Previous profile should keep its order")
+}
+
+func TestApplySnapshot_AllOrNone(t *testing.T) {
+	assert := assert{t}
+	storage := NewMemoryStorage()
+
+	prefSync := newStorageSynchronizer(t, storage)
+
+	format := binding.NewString()
+	zone := &failingValue[string]{Value: xbinding.NewValue[string]()}
+
+	assert.NoError(prefSync.AddString(StringPreference{Key: "format", Value: format, Fallback: "2006"}), "AddString should not return an error")
+	assert.NoError(Add[string](prefSync, Preference[string]{Key: "zone", Value: zone, Fallback: "UTC", Codec: StringCodec{}}), "Add should not return an error")
+
+	assert.Error(prefSync.ApplySnapshot(Snapshot{"format": []byte(`"15:04"`), "zone": []byte(`1`)}), "Invalid value should return an error")
+	value, _ := format.Get()
+	assert.Equal("2006", value, "Nothing should be set when a value is invalid")
+
+	assert.Error(prefSync.ApplySnapshot(Snapshot{"format": []byte(`"15:04"`), "unknown": []byte(`1`)}), "Unknown key should return an error")
+	value, _ = format.Get()
+	assert.Equal("2006", value, "Nothing should be set when a key is unknown")
+
+	zone.setErr = errors.New("cannot set")
+	assert.Error(prefSync.ApplySnapshot(Snapshot{"format": []byte(`"15:04"`), "zone": []byte(`"Europe/Warsaw"`)}), "Failing binding should return an error")
+	value, _ = format.Get()
+	assert.Equal("2006", value, "Values set before the failing one should be set back")
+
+	waitForListeners(t)
+	assert.Equal("2006", storage.String("format"), "Value set back should be stored")
+}
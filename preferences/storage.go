@@ -0,0 +1,390 @@
+package preferences
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Storage keeps preference values, fyne.Preferences implements it,
+// so fyne app preferences can be passed as is
+type Storage interface {
+	Bool(key string) bool
+	BoolWithFallback(key string, fallback bool) bool
+	SetBool(key string, value bool)
+
+	Float(key string) float64
+	FloatWithFallback(key string, fallback float64) float64
+	SetFloat(key string, value float64)
+
+	Int(key string) int
+	IntWithFallback(key string, fallback int) int
+	SetInt(key string, value int)
+
+	String(key string) string
+	StringWithFallback(key, fallback string) string
+	SetString(key string, value string)
+
+	RemoveValue(key string)
+}
+
+// Storage which tells when its values change, also when they are changed
+// by someone else, e.g. another instance of the app, fyne.Preferences implements it
+// Listener must not write values
+type WatchedStorage interface {
+	Storage
+	AddChangeListener(listener func())
+}
+
+// Storage which keeps values in memory only, fits tests and one-off tools
+type MemoryStorage struct {
+	values    map[string]interface{}
+	listeners []func()
+	lock      sync.RWMutex
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		values: make(map[string]interface{}),
+	}
+}
+
+func (m *MemoryStorage) get(key string) (interface{}, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	value, ok := m.values[key]
+	return value, ok
+}
+
+func (m *MemoryStorage) set(key string, value interface{}) {
+	m.lock.Lock()
+
+	if stored, ok := m.values[key]; ok && stored == value {
+		m.lock.Unlock()
+		return
+	}
+
+	m.values[key] = value
+	m.lock.Unlock()
+
+	m.fireChange()
+}
+
+func (m *MemoryStorage) AddChangeListener(listener func()) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.listeners = append(m.listeners, listener)
+}
+
+func (m *MemoryStorage) fireChange() {
+	m.lock.RLock()
+	listeners := append([]func(){}, m.listeners...)
+	m.lock.RUnlock()
+
+	for _, listener := range listeners {
+		listener()
+	}
+}
+
+func (m *MemoryStorage) Bool(key string) bool {
+	return m.BoolWithFallback(key, false)
+}
+
+func (m *MemoryStorage) BoolWithFallback(key string, fallback bool) bool {
+	if value, ok := m.get(key); ok {
+		if b, ok := value.(bool); ok {
+			return b
+		}
+	}
+
+	return fallback
+}
+
+func (m *MemoryStorage) SetBool(key string, value bool) {
+	m.set(key, value)
+}
+
+func (m *MemoryStorage) Float(key string) float64 {
+	return m.FloatWithFallback(key, 0)
+}
+
+func (m *MemoryStorage) FloatWithFallback(key string, fallback float64) float64 {
+	if value, ok := m.get(key); ok {
+		switch v := value.(type) {
+		case float64:
+			return v
+		case int:
+			return float64(v)
+		}
+	}
+
+	return fallback
+}
+
+func (m *MemoryStorage) SetFloat(key string, value float64) {
+	m.set(key, value)
+}
+
+func (m *MemoryStorage) Int(key string) int {
+	return m.IntWithFallback(key, 0)
+}
+
+func (m *MemoryStorage) IntWithFallback(key string, fallback int) int {
+	if value, ok := m.get(key); ok {
+		switch v := value.(type) {
+		case int:
+			return v
+		// numbers read from JSON
+		case float64:
+			return int(v)
+		}
+	}
+
+	return fallback
+}
+
+func (m *MemoryStorage) SetInt(key string, value int) {
+	m.set(key, value)
+}
+
+func (m *MemoryStorage) String(key string) string {
+	return m.StringWithFallback(key, "")
+}
+
+func (m *MemoryStorage) StringWithFallback(key string, fallback string) string {
+	if value, ok := m.get(key); ok {
+		if s, ok := value.(string); ok {
+			return s
+		}
+	}
+
+	return fallback
+}
+
+func (m *MemoryStorage) SetString(key string, value string) {
+	m.set(key, value)
+}
+
+func (m *MemoryStorage) RemoveValue(key string) {
+	m.lock.Lock()
+
+	if _, ok := m.values[key]; !ok {
+		m.lock.Unlock()
+		return
+	}
+
+	delete(m.values, key)
+	m.lock.Unlock()
+
+	m.fireChange()
+}
+
+// Storage which keeps values in a plain JSON file, every change is written
+// right away, so use it together with debounce of the synchronizer
+type FileStorage struct {
+	*MemoryStorage
+	path string
+	// last error of writing the file, setters have no way to return it
+	err error
+	// held while a value is changed and written, and while the file is reloaded,
+	// so reload never reads the file before a change is written to it
+	fileLock sync.Mutex
+}
+
+// Returns path of preferences file of given app in user config directory,
+// the one fyne uses, e.g. $XDG_CONFIG_HOME/fyne/<appID>/preferences.json on Linux
+func DefaultFilePath(appID string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "fyne", appID, "preferences.json"), nil
+}
+
+// Opens file storage, missing file is fine, it is created on first change
+func NewFileStorage(path string) (*FileStorage, error) {
+	f := &FileStorage{
+		MemoryStorage: NewMemoryStorage(),
+		path:          path,
+	}
+
+	values, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	f.values = values
+
+	return f, nil
+}
+
+// Reads values from the file, missing file has no values
+func (f *FileStorage) read() (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", f.path, err)
+	}
+
+	return values, nil
+}
+
+// Reads the file again, e.g. after another instance changed it,
+// change listeners are called if any value differs
+func (f *FileStorage) Reload() error {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+
+	values, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	if reflect.DeepEqual(f.values, values) {
+		f.lock.Unlock()
+		return nil
+	}
+
+	f.values = values
+	f.lock.Unlock()
+
+	slog.Debug("preferences file changed", "path", f.path)
+	f.fireChange()
+
+	return nil
+}
+
+// Reloads the file whenever its modification time changes, checked every interval,
+// returned function stops watching
+func (f *FileStorage) Watch(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	modTime := func() time.Time {
+		info, err := os.Stat(f.path)
+		if err != nil {
+			return time.Time{}
+		}
+
+		return info.ModTime()
+	}
+
+	last := modTime()
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				current := modTime()
+				if current.Equal(last) {
+					continue
+				}
+
+				last = current
+				if err := f.Reload(); err != nil {
+					slog.Warn("preferences file cannot be reloaded", "path", f.path, "err", err)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// Returns error of the last failed write, nil if it succeeded
+func (f *FileStorage) Err() error {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.err
+}
+
+// Writes all values to the file, through temporary file,
+// so it is never left half written
+func (f *FileStorage) save() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.err = func() error {
+		data, err := json.MarshalIndent(f.values, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+			return err
+		}
+
+		tmp := f.path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0o644); err != nil {
+			return err
+		}
+
+		return os.Rename(tmp, f.path)
+	}()
+}
+
+func (f *FileStorage) SetBool(key string, value bool) {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+
+	f.MemoryStorage.SetBool(key, value)
+	f.save()
+}
+
+func (f *FileStorage) SetFloat(key string, value float64) {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+
+	f.MemoryStorage.SetFloat(key, value)
+	f.save()
+}
+
+func (f *FileStorage) SetInt(key string, value int) {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+
+	f.MemoryStorage.SetInt(key, value)
+	f.save()
+}
+
+func (f *FileStorage) SetString(key string, value string) {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+
+	f.MemoryStorage.SetString(key, value)
+	f.save()
+}
+
+func (f *FileStorage) RemoveValue(key string) {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+
+	f.MemoryStorage.RemoveValue(key)
+	f.save()
+}
@@ -0,0 +1,184 @@
+package preferences
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+)
+
+// Checks values of all types survive in given storage
+func checkStorageRoundTrip(t *testing.T, storage Storage) {
+	assert := assert{t}
+
+	assert.Equal(true, storage.BoolWithFallback("bool", true), "Missing bool should return fallback")
+	assert.Equal(3, storage.IntWithFallback("int", 3), "Missing int should return fallback")
+	assert.Equal(1.5, storage.FloatWithFallback("float", 1.5), "Missing float should return fallback")
+	assert.Equal("fallback", storage.StringWithFallback("string", "fallback"), "Missing string should return fallback")
+
+	storage.SetBool("bool", false)
+	storage.SetInt("int", 42)
+	storage.SetFloat("float", 2.25)
+	storage.SetString("string", "value")
+
+	assert.Equal(false, storage.BoolWithFallback("bool", true), "Stored bool should be returned")
+	assert.Equal(42, storage.IntWithFallback("int", 3), "Stored int should be returned")
+	assert.Equal(2.25, storage.FloatWithFallback("float", 1.5), "Stored float should be returned")
+	assert.Equal("value", storage.StringWithFallback("string", "fallback"), "Stored string should be returned")
+
+	storage.RemoveValue("string")
+	assert.Equal("", storage.String("string"), "Removed string should be empty")
+}
+
+func TestStorage_Memory(t *testing.T) {
+	checkStorageRoundTrip(t, NewMemoryStorage())
+}
+
+func TestStorage_Fyne(t *testing.T) {
+	checkStorageRoundTrip(t, test.NewApp().Preferences())
+}
+
+func TestDefaultFilePath(t *testing.T) {
+	path, err := DefaultFilePath("com.example.app")
+	if err != nil {
+		t.Skipf("no user config directory: %v", err)
+	}
+
+	// the same file fyne keeps preferences of the app in
+	want := filepath.Join("fyne", "com.example.app", "preferences.json")
+	if !strings.HasSuffix(path, want) {
+		t.Fatalf("expected path ending with %s, got %s", want, path)
+	}
+}
+
+func TestStorage_File(t *testing.T) {
+	assert := assert{t}
+	path := filepath.Join(t.TempDir(), "app", "preferences.json")
+
+	storage, err := NewFileStorage(path)
+	assert.NoError(err, "Missing file should not be an error")
+
+	checkStorageRoundTrip(t, storage)
+	assert.NoError(storage.Err(), "Writes should not fail")
+
+	reopened, err := NewFileStorage(path)
+	assert.NoError(err, "Written file should be readable")
+
+	assert.Equal(42, reopened.Int("int"), "Int should survive reopening")
+	assert.Equal(2.25, reopened.Float("float"), "Float should survive reopening")
+	assert.Equal(false, reopened.BoolWithFallback("bool", true), "Bool should survive reopening")
+	assert.Equal("", reopened.String("string"), "Removed value should stay removed")
+
+	_, err = os.Stat(path + ".tmp")
+	assert.True(os.IsNotExist(err), "Temporary file should not be left behind")
+}
+
+func TestStorage_File_Corrupted(t *testing.T) {
+	assert := assert{t}
+	path := filepath.Join(t.TempDir(), "preferences.json")
+
+	assert.NoError(os.WriteFile(path, []byte("{not json"), 0o644), "Writing test file should not fail")
+
+	_, err := NewFileStorage(path)
+	assert.Error(err, "Corrupted file should return an error")
+}
+
+func TestStorage_File_Synchronizer(t *testing.T) {
+	assert := assert{t}
+	path := filepath.Join(t.TempDir(), "preferences.json")
+
+	storage, err := NewFileStorage(path)
+	assert.NoError(err, "NewFileStorage should not return an error")
+
+	prefSync := newStorageSynchronizer(t, storage)
+
+	value := binding.NewString()
+	assert.NoError(prefSync.AddString(StringPreference{
+		Key: "format", Value: value, Fallback: "RFC3339",
+	}), "AddString should not return an error")
+
+	value.Set("Kitchen")
+	assert.NoError(prefSync.Flush(), "Flush should not return an error")
+
+	eventually(t, func() bool {
+		reopened, err := NewFileStorage(path)
+		return err == nil && reopened.String("format") == "Kitchen"
+	}, "Value should be written to the file")
+
+	reopened, _ := NewFileStorage(path)
+	assert.Equal(DefaultMigrations.Latest(), reopened.Int(SchemaVersionKey), "Schema version should be stored in the file")
+}
+
+func TestStorage_File_Reload(t *testing.T) {
+	assert := assert{t}
+	path := filepath.Join(t.TempDir(), "preferences.json")
+
+	storage, err := NewFileStorage(path)
+	assert.NoError(err, "NewFileStorage should not return an error")
+
+	changes := 0
+	storage.AddChangeListener(func() { changes++ })
+
+	// another instance writing the same file
+	other, err := NewFileStorage(path)
+	assert.NoError(err, "NewFileStorage should not return an error")
+	other.SetString("format", "Kitchen")
+
+	assert.NoError(storage.Reload(), "Reload should not return an error")
+	assert.Equal("Kitchen", storage.String("format"), "Reload should read values written by another instance")
+	assert.Equal(1, changes, "Reload should notify about the change")
+
+	assert.NoError(storage.Reload(), "Second Reload should not return an error")
+	assert.Equal(1, changes, "Reload without changes should not notify")
+}
+
+func TestStorage_File_ReloadWhileWriting(t *testing.T) {
+	assert := assert{t}
+	path := filepath.Join(t.TempDir(), "preferences.json")
+
+	storage, err := NewFileStorage(path)
+	assert.NoError(err, "NewFileStorage should not return an error")
+
+	// reload running between a change and its write would bring back the previous value
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= 200; i++ {
+			storage.SetInt("count", i)
+			if storage.Int("count") != i {
+				t.Errorf("value set should not be replaced by reload, got %d, want %d", storage.Int("count"), i)
+				return
+			}
+		}
+	}()
+
+	for reloading := true; reloading; {
+		select {
+		case <-done:
+			reloading = false
+		default:
+			assert.NoError(storage.Reload(), "Reload should not return an error")
+		}
+	}
+
+	assert.Equal(200, storage.Int("count"), "Last value should be kept")
+}
+
+func TestStorage_File_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "preferences.json")
+
+	storage, _ := NewFileStorage(path)
+	stop := storage.Watch(5 * time.Millisecond)
+	defer stop()
+
+	other, _ := NewFileStorage(path)
+	other.SetString("format", "Kitchen")
+
+	eventually(t, func() bool {
+		return storage.String("format") == "Kitchen"
+	}, "Watched storage should pick up changes of the file")
+}
@@ -0,0 +1,31 @@
+package preferences
+
+import "fmt"
+
+// Returned when preference value is rejected by its validator
+type ValidationError struct {
+	Key   string
+	Value interface{}
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid value %v of %s: %v", e.Value, e.Key, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Returns validator which accepts only given values
+func OneOf[T comparable](allowed ...T) func(T) error {
+	return func(value T) error {
+		for _, a := range allowed {
+			if value == a {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%v is not one of %v", value, allowed)
+	}
+}
@@ -0,0 +1,189 @@
+package preferences
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// Collects errors passed to the error handler
+type errorCollector struct {
+	lock   sync.Mutex
+	errors []error
+}
+
+func (c *errorCollector) handle(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.errors = append(c.errors, err)
+}
+
+func (c *errorCollector) count() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.errors)
+}
+
+func newValidatedSynchronizer(t *testing.T, storage Storage) (*PreferencesSynchronizer, binding.String, *errorCollector) {
+	assert := assert{t}
+
+	prefSync := newStorageSynchronizer(t, storage)
+
+	collector := &errorCollector{}
+	prefSync.SetErrorHandler(collector.handle)
+
+	value := binding.NewString()
+	assert.NoError(prefSync.AddString(StringPreference{
+		Key:       "theme",
+		Value:     value,
+		Fallback:  "system",
+		Validate:  OneOf("system", "light", "dark"),
+		Normalize: strings.ToLower,
+	}), "AddString should not return an error")
+
+	return prefSync, value, collector
+}
+
+func TestValidation_OnLoad(t *testing.T) {
+	assert := assert{t}
+
+	storage := NewMemoryStorage()
+	storage.SetString("theme", "purple")
+
+	_, value, collector := newValidatedSynchronizer(t, storage)
+
+	v, _ := value.Get()
+	assert.Equal("system", v, "Invalid stored value should be replaced with fallback")
+	assert.Equal(1, collector.count(), "Invalid stored value should be reported")
+
+	var validationErr *ValidationError
+	assert.True(errors.As(collector.errors[0], &validationErr), "Reported error should be ValidationError")
+	assert.Equal("theme", validationErr.Key, "Error should name the key")
+	assert.Equal("purple", validationErr.Value, "Error should carry the rejected value")
+}
+
+func TestValidation_OnLoad_Normalized(t *testing.T) {
+	assert := assert{t}
+
+	storage := NewMemoryStorage()
+	storage.SetString("theme", "DARK")
+
+	_, value, collector := newValidatedSynchronizer(t, storage)
+
+	v, _ := value.Get()
+	assert.Equal("dark", v, "Stored value should be normalized")
+	assert.Equal(0, collector.count(), "Normalized value should not be reported")
+}
+
+func TestValidation_OnSet(t *testing.T) {
+	assert := assert{t}
+	storage := NewMemoryStorage()
+
+	_, value, collector := newValidatedSynchronizer(t, storage)
+
+	value.Set("Light")
+	eventually(t, func() bool {
+		v, _ := value.Get()
+		return v == "light" && storage.String("theme") == "light"
+	}, "Set value should be normalized and stored")
+
+	value.Set("purple")
+	eventually(t, func() bool {
+		v, _ := value.Get()
+		return v == "system" && storage.String("theme") == "system"
+	}, "Invalid value should be replaced with fallback")
+
+	assert.Equal(1, collector.count(), "Invalid value should be reported")
+	assert.NotEqual("purple", storage.String("theme"), "Invalid value should not be stored")
+}
+
+func TestValidation_InvalidFallback(t *testing.T) {
+	assert := assert{t}
+
+	prefSync := newStorageSynchronizer(t, NewMemoryStorage())
+
+	assert.Error(prefSync.AddInt(IntPreference{
+		Key:      "int",
+		Value:    binding.NewInt(),
+		Fallback: -1,
+		Validate: func(v int) error {
+			if v < 0 {
+				return fmt.Errorf("negative")
+			}
+			return nil
+		},
+	}), "Invalid fallback should be rejected")
+}
+
+func TestValidation_Bool(t *testing.T) {
+	assert := assert{t}
+	storage := NewMemoryStorage()
+	storage.SetBool("flag", true)
+
+	prefSync := newStorageSynchronizer(t, storage)
+
+	// e.g. option which cannot be turned on in this build
+	flag := binding.NewBool()
+	assert.NoError(prefSync.AddBool(BoolPreference{
+		Key:       "flag",
+		Value:     flag,
+		Normalize: func(bool) bool { return false },
+	}), "AddBool should not return an error")
+
+	value, _ := flag.Get()
+	assert.False(value, "Stored value should be normalized")
+
+	assert.Error(prefSync.AddBool(BoolPreference{
+		Key:      "other",
+		Value:    binding.NewBool(),
+		Fallback: true,
+		Validate: func(v bool) error {
+			if v {
+				return fmt.Errorf("cannot be turned on")
+			}
+			return nil
+		},
+	}), "Invalid fallback should be rejected")
+}
+
+func TestValidation_Import(t *testing.T) {
+	assert := assert{t}
+
+	prefSync, value, _ := newValidatedSynchronizer(t, NewMemoryStorage())
+
+	plan, err := prefSync.PrepareImport([]byte(`{"version": 1, "preferences": {"theme": "purple"}}`))
+	assert.NoError(err, "PrepareImport should not return an error")
+	assert.Equal(0, len(plan.Changes), "Invalid value should not be imported")
+	assert.Equal(1, len(plan.Invalid), "Invalid value should be listed")
+
+	assert.NoError(plan.Apply(), "Apply should not return an error")
+
+	v, _ := value.Get()
+	assert.Equal("system", v, "Value should not change")
+}
+
+func TestValidation_OneOf(t *testing.T) {
+	validate := OneOf(1, 2, 3)
+
+	tests := []struct {
+		value int
+		valid bool
+	}{
+		{1, true},
+		{3, true},
+		{0, false},
+		{4, false},
+	}
+
+	for _, tt := range tests {
+		if err := validate(tt.value); (err == nil) != tt.valid {
+			t.Errorf("OneOf(1, 2, 3)(%d) = %v, valid should be %v", tt.value, err, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package timezone
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// Julian Date 0 is noon of 1 January 4713 BC in proleptic Julian
+	// calendar, which is 24 November 4714 BC in proleptic Gregorian one,
+	// year 0 exists in Go, so it is -4713
+	julianDateEpoch = time.Date(-4713, time.November, 24, 12, 0, 0, 0, time.UTC)
+	// Modified Julian Date 0 is JD 2400000.5
+	modifiedJulianDateEpoch = time.Date(1858, time.November, 17, 0, 0, 0, 0, time.UTC)
+)
+
+// Terrestrial Time is ahead of TAI by constant offset
+const TTMinusTAI = 32184 * time.Millisecond
+
+var (
+	taiZone = time.FixedZone("TAI", 0)
+	ttZone  = time.FixedZone("TT", 0)
+)
+
+// Zone elements of layouts, longest first, so "-07:00" is not taken for "-07"
+var layoutZoneElements = []string{
+	"Z07:00:00", "-07:00:00", "Z07:00", "-07:00", "Z0700", "-0700", "Z07", "-07", "MST",
+}
+
+// Returns layout for rows of time scales, zone of the layout is replaced
+// with name of the scale, offset 0 of the scale would look like UTC
+// Layout of other rows is returned as it is
+func ScaleLayout(layout string, tzType TimezoneType) string {
+	var zone *time.Location
+
+	switch tzType {
+	case TAITimezoneType:
+		zone = taiZone
+	case TTTimezoneType:
+		zone = ttZone
+	default:
+		return layout
+	}
+
+	for _, element := range layoutZoneElements {
+		layout = strings.ReplaceAll(layout, element, "")
+	}
+
+	return strings.Join(strings.Fields(layout), " ") + " " + zone.String()
+}
+
+// Returns Julian Date of given instant, counted in UTC
+func ToJulianDate(t time.Time) float64 {
+	return fractionalDays(t.UTC(), julianDateEpoch)
+}
+
+// Returns Modified Julian Date of given instant, counted in UTC
+func ToModifiedJulianDate(t time.Time) float64 {
+	return fractionalDays(t.UTC(), modifiedJulianDateEpoch)
+}
+
+// Converts Julian Date to time in UTC
+func FromJulianDate(jd float64) (time.Time, error) {
+	return fromDays(julianDateEpoch, jd, "JD")
+}
+
+// Converts Modified Julian Date to time in UTC
+func FromModifiedJulianDate(mjd float64) (time.Time, error) {
+	return fromDays(modifiedJulianDateEpoch, mjd, "MJD")
+}
+
+// Days outside of supported range would overflow when added to epoch
+func fromDays(epoch time.Time, days float64, name string) (time.Time, error) {
+	if !(days >= fractionalDays(MinTime, epoch) && days <= fractionalDays(MaxTime, epoch)) {
+		return time.Time{}, fmt.Errorf("%s %v: %w", name, days, ErrOutOfRange)
+	}
+
+	return addFractionalDays(epoch, days), nil
+}
+
+// Returns wall clock of given instant in TAI scale
+func ToTAI(t time.Time) time.Time {
+	return t.Add(TAIMinusUTC(t)).In(taiZone)
+}
+
+// Returns wall clock of given instant in TT scale
+func ToTT(t time.Time) time.Time {
+	return t.Add(TAIMinusUTC(t) + TTMinusTAI).In(ttZone)
+}
+
+// Reads wall clock in TAI scale back as UTC instant
+func FromTAI(tai time.Time) time.Time {
+	approximate := tai.Add(-TAIMinusUTC(tai))
+
+	return tai.Add(-TAIMinusUTC(approximate)).UTC()
+}
+
+// Reads wall clock in TT scale back as UTC instant
+func FromTT(tt time.Time) time.Time {
+	return FromTAI(tt.Add(-TTMinusTAI))
+}
+
+// Returned by ParseJulian when value does not start with JD or MJD
+var ErrNoJulianPrefix = errors.New("no JD or MJD prefix")
+
+// Parses "JD 2460000.5" or "MJD 60000", prefix is case insensitive,
+// space after it is optional
+func ParseJulian(s string) (time.Time, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+
+	if strings.HasPrefix(s, "MJD") {
+		mjd, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(s, "MJD")), 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return FromModifiedJulianDate(mjd)
+	}
+
+	if strings.HasPrefix(s, "JD") {
+		jd, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(s, "JD")), 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return FromJulianDate(jd)
+	}
+
+	return time.Time{}, fmt.Errorf("%q: %w", s, ErrNoJulianPrefix)
+}
+
+// Parses bare number in date system of given row type,
+// used when number is typed directly into such a row
+func ParseNumber(s string, tzType TimezoneType) (time.Time, error) {
+	if IsSerial(tzType) {
+		return ParseSerial(s, tzType)
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch tzType {
+	case JulianDateTimezoneType:
+		return FromJulianDate(value)
+	case ModifiedJulianDateTimezoneType:
+		return FromModifiedJulianDate(value)
+	}
+
+	return time.Time{}, fmt.Errorf("timezone type %d is not a number", tzType)
+}
+
+// Tells if rows of given type show a plain number instead of formatted time
+func IsNumber(tzType TimezoneType) bool {
+	return IsSerial(tzType) ||
+		tzType == JulianDateTimezoneType ||
+		tzType == ModifiedJulianDateTimezoneType
+}
+
+// Converts wall clock read from row of given type to UTC instant,
+// only time scales differ from UTC, other rows carry their offset already
+func ScaleToUTC(t time.Time, tzType TimezoneType) time.Time {
+	switch tzType {
+	case TAITimezoneType:
+		return FromTAI(t)
+	case TTTimezoneType:
+		return FromTT(t)
+	}
+
+	return t
+}
+
+// Julian dates are shown with 8 decimals, which is below a millisecond
+func formatJulian(value float64) string {
+	formatted := strconv.FormatFloat(value, 'f', 8, 64)
+	formatted = strings.TrimRight(formatted, "0")
+
+	return strings.TrimSuffix(formatted, ".")
+}
@@ -0,0 +1,143 @@
+package timezone
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJulian(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		time  time.Time
+		jd    string
+		mjd   string
+	}{
+		{
+			name:  "J2000",
+			input: "JD 2451545",
+			time:  time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC),
+			jd:    "2451545",
+			mjd:   "51544.5",
+		},
+		{
+			name:  "JD_NoSpace",
+			input: "jd2460000.5",
+			time:  time.Date(2023, time.February, 25, 0, 0, 0, 0, time.UTC),
+			jd:    "2460000.5",
+			mjd:   "60000",
+		},
+		{
+			name:  "MJD",
+			input: "MJD 60000",
+			time:  time.Date(2023, time.February, 25, 0, 0, 0, 0, time.UTC),
+			jd:    "2460000.5",
+			mjd:   "60000",
+		},
+		{
+			name:  "MJD_Epoch",
+			input: "MJD 0",
+			time:  time.Date(1858, time.November, 17, 0, 0, 0, 0, time.UTC),
+			jd:    "2400000.5",
+			mjd:   "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJulian(tt.input)
+			if err != nil {
+				t.Fatalf("ParseJulian() error = %v", err)
+			}
+
+			if !got.Equal(tt.time) {
+				t.Errorf("ParseJulian() = %v, want %v", got, tt.time)
+			}
+
+			jd := TimezoneDefinition{Type: JulianDateTimezoneType}
+			if formatted := jd.StringTime(tt.time, time.RFC3339); formatted != tt.jd {
+				t.Errorf("JD StringTime() = %v, want %v", formatted, tt.jd)
+			}
+
+			mjd := TimezoneDefinition{Type: ModifiedJulianDateTimezoneType}
+			if formatted := mjd.StringTime(tt.time, time.RFC3339); formatted != tt.mjd {
+				t.Errorf("MJD StringTime() = %v, want %v", formatted, tt.mjd)
+			}
+		})
+	}
+
+	if _, err := ParseJulian("2460000.5"); err == nil {
+		t.Errorf("ParseJulian() without prefix expected error")
+	}
+
+	if _, err := ParseJulian("JD 1e20"); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("ParseJulian() = %v, want ErrOutOfRange", err)
+	}
+}
+
+func TestTAIMinusUTC(t *testing.T) {
+	tests := []struct {
+		name string
+		time time.Time
+		want time.Duration
+	}{
+		{"BeforeUTC", time.Date(1950, time.January, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"RubberSeconds", time.Date(1965, time.January, 1, 0, 0, 0, 0, time.UTC), 3540130 * time.Microsecond},
+		{"Start1972", time.Date(1972, time.January, 1, 0, 0, 0, 0, time.UTC), 10 * time.Second},
+		{"BeforeLeap2016", time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC), 36 * time.Second},
+		{"AfterLeap2016", time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), 37 * time.Second},
+		{"Recent", time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC), 37 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TAIMinusUTC(tt.time); got != tt.want {
+				t.Errorf("TAIMinusUTC() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScaleLayout(t *testing.T) {
+	tests := []struct {
+		layout string
+		tzType TimezoneType
+		want   string
+	}{
+		{layout: time.RFC3339, tzType: TAITimezoneType, want: "2006-01-02T15:04:05 TAI"},
+		{layout: time.RubyDate, tzType: TTTimezoneType, want: "Mon Jan 02 15:04:05 2006 TT"},
+		{layout: time.RFC822Z, tzType: TAITimezoneType, want: "02 Jan 06 15:04 TAI"},
+		{layout: time.RFC1123Z, tzType: TTTimezoneType, want: "Mon, 02 Jan 2006 15:04:05 TT"},
+		{layout: time.RFC1123, tzType: TAITimezoneType, want: "Mon, 02 Jan 2006 15:04:05 TAI"},
+		{layout: time.RFC3339, tzType: UnixTimezoneType, want: time.RFC3339},
+	}
+
+	for _, tt := range tests {
+		if got := ScaleLayout(tt.layout, tt.tzType); got != tt.want {
+			t.Errorf("ScaleLayout(%q, %v) = %q, want %q", tt.layout, tt.tzType, got, tt.want)
+		}
+	}
+}
+
+func TestTimeScales(t *testing.T) {
+	utc := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tai := TimezoneDefinition{Type: TAITimezoneType}
+	if got := tai.StringTime(utc, time.RFC3339); got != "2023-06-01T12:00:37 TAI" {
+		t.Errorf("TAI StringTime() = %v", got)
+	}
+
+	tt := TimezoneDefinition{Type: TTTimezoneType}
+	if got := tt.StringTime(utc, time.RFC3339Nano); got != "2023-06-01T12:01:09.184 TT" {
+		t.Errorf("TT StringTime() = %v", got)
+	}
+
+	if got := FromTAI(ToTAI(utc)); !got.Equal(utc) {
+		t.Errorf("FromTAI(ToTAI()) = %v, want %v", got, utc)
+	}
+
+	if got := FromTT(ToTT(utc)); !got.Equal(utc) {
+		t.Errorf("FromTT(ToTT()) = %v, want %v", got, utc)
+	}
+}
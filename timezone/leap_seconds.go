@@ -0,0 +1,178 @@
+package timezone
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"time"
+)
+
+// Leap second inserted at the end of the day before At,
+// from At on TAI is ahead of UTC by TAIMinusUTC seconds
+type LeapSecond struct {
+	At          time.Time
+	TAIMinusUTC int
+}
+
+// Time of the inserted second itself, in UTC notation it is 23:59:60,
+// which time.Time cannot hold, so it returns the 23:59:59 before it
+func (l LeapSecond) Inserted() time.Time {
+	return l.At.Add(-time.Second)
+}
+
+func leapSecondAt(year int, month time.Month, taiMinusUTC int) LeapSecond {
+	return LeapSecond{
+		At:          time.Date(year, month, 1, 0, 0, 0, 0, time.UTC),
+		TAIMinusUTC: taiMinusUTC,
+	}
+}
+
+// Leap seconds table since UTC got whole second offsets to TAI,
+// first entry is the initial 10 s offset of 1972, not a leap second
+// Source: IERS Bulletin C, no leap second was announced after 2017
+var LeapSeconds = []LeapSecond{
+	leapSecondAt(1972, time.January, 10),
+	leapSecondAt(1972, time.July, 11),
+	leapSecondAt(1973, time.January, 12),
+	leapSecondAt(1974, time.January, 13),
+	leapSecondAt(1975, time.January, 14),
+	leapSecondAt(1976, time.January, 15),
+	leapSecondAt(1977, time.January, 16),
+	leapSecondAt(1978, time.January, 17),
+	leapSecondAt(1979, time.January, 18),
+	leapSecondAt(1980, time.January, 19),
+	leapSecondAt(1981, time.July, 20),
+	leapSecondAt(1982, time.July, 21),
+	leapSecondAt(1983, time.July, 22),
+	leapSecondAt(1985, time.July, 23),
+	leapSecondAt(1988, time.January, 24),
+	leapSecondAt(1990, time.January, 25),
+	leapSecondAt(1991, time.January, 26),
+	leapSecondAt(1992, time.July, 27),
+	leapSecondAt(1993, time.July, 28),
+	leapSecondAt(1994, time.July, 29),
+	leapSecondAt(1996, time.January, 30),
+	leapSecondAt(1997, time.July, 31),
+	leapSecondAt(1999, time.January, 32),
+	leapSecondAt(2006, time.January, 33),
+	leapSecondAt(2009, time.January, 34),
+	leapSecondAt(2012, time.July, 35),
+	leapSecondAt(2015, time.July, 36),
+	leapSecondAt(2017, time.January, 37),
+}
+
+// Between 1961 and 1972 UTC seconds were stretched to follow Earth rotation,
+// TAI-UTC = offset + (MJD - referenceMJD) * rate
+type rubberSecondsPeriod struct {
+	from         time.Time
+	offset       float64
+	referenceMJD float64
+	rate         float64
+}
+
+func rubberSecondsFrom(year int, month time.Month, offset float64, referenceMJD float64, rate float64) rubberSecondsPeriod {
+	return rubberSecondsPeriod{
+		from:         time.Date(year, month, 1, 0, 0, 0, 0, time.UTC),
+		offset:       offset,
+		referenceMJD: referenceMJD,
+		rate:         rate,
+	}
+}
+
+// Source: USNO tai-utc.dat
+var rubberSeconds = []rubberSecondsPeriod{
+	rubberSecondsFrom(1961, time.January, 1.4228180, 37300, 0.001296),
+	rubberSecondsFrom(1961, time.August, 1.3728180, 37300, 0.001296),
+	rubberSecondsFrom(1962, time.January, 1.8458580, 37665, 0.0011232),
+	rubberSecondsFrom(1963, time.November, 1.9458580, 37665, 0.0011232),
+	rubberSecondsFrom(1964, time.January, 3.2401300, 38761, 0.001296),
+	rubberSecondsFrom(1964, time.April, 3.3401300, 38761, 0.001296),
+	rubberSecondsFrom(1964, time.September, 3.4401300, 38761, 0.001296),
+	rubberSecondsFrom(1965, time.January, 3.5401300, 38761, 0.001296),
+	rubberSecondsFrom(1965, time.March, 3.6401300, 38761, 0.001296),
+	rubberSecondsFrom(1965, time.July, 3.7401300, 38761, 0.001296),
+	rubberSecondsFrom(1965, time.September, 3.8401300, 38761, 0.001296),
+	rubberSecondsFrom(1966, time.January, 4.3131700, 39126, 0.002592),
+	rubberSecondsFrom(1968, time.February, 4.2131700, 39126, 0.002592),
+}
+
+// Returns TAI-UTC at given instant
+// Before 1961 UTC did not exist, zero is returned then
+func TAIMinusUTC(t time.Time) time.Duration {
+	for i := len(LeapSeconds) - 1; i >= 0; i-- {
+		if !t.Before(LeapSeconds[i].At) {
+			return time.Duration(LeapSeconds[i].TAIMinusUTC) * time.Second
+		}
+	}
+
+	for i := len(rubberSeconds) - 1; i >= 0; i-- {
+		period := rubberSeconds[i]
+		if !t.Before(period.from) {
+			seconds := period.offset + (ToModifiedJulianDate(t)-period.referenceMJD)*period.rate
+			return time.Duration(math.Round(seconds * float64(time.Second)))
+		}
+	}
+
+	return 0
+}
+
+// Returns leap seconds inserted between two instants, order of them does not matter
+func LeapSecondsBetween(a time.Time, b time.Time) []LeapSecond {
+	if b.Before(a) {
+		a, b = b, a
+	}
+
+	ret := make([]LeapSecond, 0)
+
+	// first entry is not a leap second, it is where the table starts
+	for _, leap := range LeapSeconds[1:] {
+		if leap.At.After(a) && !leap.At.After(b) {
+			ret = append(ret, leap)
+		}
+	}
+
+	return ret
+}
+
+// Tells if given instant is the 23:59:59 UTC just before a leap second
+func IsBeforeLeapSecond(t time.Time) bool {
+	for _, leap := range LeapSeconds[1:] {
+		if t.Equal(leap.Inserted()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returned by ParseLeapSecond when value has no 60 in seconds field
+var ErrNoLeapSecondField = errors.New("no leap second in seconds field")
+
+// seconds field of hh:mm:ss equal to 60
+var leapSecondField = regexp.MustCompile(`\d{2}:\d{2}(:60)(?:[^0-9]|$)`)
+
+// Parses time with 60 in seconds field, which time.Parse rejects
+// Leap second is mapped to the 23:59:59 UTC before it, same as LeapSecond.Inserted,
+// time not matching any leap second from the table is an error
+func ParseLeapSecond(layout string, value string) (time.Time, error) {
+	loc := leapSecondField.FindStringSubmatchIndex(value)
+	if loc == nil {
+		return time.Time{}, fmt.Errorf("%q: %w", value, ErrNoLeapSecondField)
+	}
+
+	replaced := value[:loc[2]] + ":59" + value[loc[3]:]
+
+	t, err := time.Parse(layout, replaced)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !IsBeforeLeapSecond(t.UTC().Truncate(time.Second)) {
+		return time.Time{}, fmt.Errorf("no leap second was inserted at %s:60 UTC", t.UTC().Format("2006-01-02 15:04"))
+	}
+
+	slog.Debug("leap second read as the second before it", "value", value, "time", t)
+	return t, nil
+}
@@ -0,0 +1,77 @@
+package timezone
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLeapSecond(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:   "UTC",
+			layout: time.RFC3339,
+			value:  "2016-12-31T23:59:60Z",
+			want:   time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name:   "WithOffset",
+			layout: time.RFC3339,
+			value:  "2017-01-01T00:59:60+01:00",
+			want:   time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name:   "Fraction",
+			layout: time.RFC3339,
+			value:  "2015-06-30T23:59:60.5Z",
+			want:   time.Date(2015, time.June, 30, 23, 59, 59, 500000000, time.UTC),
+		},
+		{
+			name:    "NotInTable",
+			layout:  time.RFC3339,
+			value:   "2020-12-31T23:59:60Z",
+			wantErr: true,
+		},
+		{
+			name:    "MinuteField",
+			layout:  time.RFC3339,
+			value:   "2016-12-31T23:60:00Z",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLeapSecond(tt.layout, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLeapSecond() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("ParseLeapSecond() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeapSecondsBetween(t *testing.T) {
+	from := time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	if got := len(LeapSecondsBetween(from, to)); got != 2 {
+		t.Errorf("LeapSecondsBetween() = %d leap seconds, want 2", got)
+	}
+
+	if got := len(LeapSecondsBetween(to, from)); got != 2 {
+		t.Errorf("LeapSecondsBetween() reversed = %d leap seconds, want 2", got)
+	}
+
+	if got := len(LeapSecondsBetween(to, to.AddDate(1, 0, 0))); got != 0 {
+		t.Errorf("LeapSecondsBetween() after 2017 = %d leap seconds, want 0", got)
+	}
+}
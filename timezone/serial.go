@@ -0,0 +1,182 @@
+package timezone
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+var (
+	// Excel 1900 date system, serial 1 is 1900-01-01, Excel pretends
+	// 1900-02-29 exists (serial 60), so from serial 61 on the epoch is
+	// one day earlier, which is the same epoch as OLE Automation dates
+	excel1900Epoch     = time.Date(1899, time.December, 31, 0, 0, 0, 0, time.UTC)
+	excel1900LateEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	// Excel 1904 date system (old Mac spreadsheets), serial 0 is 1904-01-01
+	excel1904Epoch = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// OLE Automation date, serial 0 is 1899-12-30, can be negative
+	oleEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+)
+
+const (
+	// serial of 9999-12-31 in the 1900 system, last day spreadsheets accept
+	excel1900MaxSerial = 2958465
+	// serial of 9999-12-31 in the 1904 system
+	excel1904MaxSerial = 2957003
+	// serial of 0100-01-01, first day OLE Automation dates can hold
+	oleMinSerial = -657434
+	// serial of 9999-12-31 in OLE Automation dates
+	oleMaxSerial = 2958465
+)
+
+// Returns serial of given time in Excel 1900 date system,
+// time is taken as UTC wall clock
+func ToExcelSerial1900(t time.Time) float64 {
+	serial := fractionalDays(t.UTC(), excel1900LateEpoch)
+	if serial < 61 {
+		// before phantom 1900-02-29
+		return fractionalDays(t.UTC(), excel1900Epoch)
+	}
+
+	return serial
+}
+
+// Returns serial of given time in Excel 1904 date system,
+// time is taken as UTC wall clock
+func ToExcelSerial1904(t time.Time) float64 {
+	return fractionalDays(t.UTC(), excel1904Epoch)
+}
+
+// Returns OLE Automation date of given time, time is taken as UTC wall clock
+// For dates before 1899-12-30 integer part counts days backwards,
+// while fraction is still time after midnight, e.g. -1.25 is 1899-12-29 06:00
+func ToOLEAutomationDate(t time.Time) float64 {
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	days := math.Round(fractionalDays(midnight, oleEpoch))
+	fraction := fractionalDays(t, midnight)
+
+	if days < 0 {
+		return days - fraction
+	}
+
+	return days + fraction
+}
+
+// Converts serial in Excel 1900 date system to time in UTC
+// Serial 60 is the non existing 1900-02-29, it is mapped to 1900-03-01
+func FromExcelSerial1900(serial float64) (time.Time, error) {
+	if math.IsNaN(serial) || serial < 0 || serial >= excel1900MaxSerial+1 {
+		return time.Time{}, fmt.Errorf("excel serial %v out of range", serial)
+	}
+
+	if serial < 61 {
+		return addFractionalDays(excel1900Epoch, serial), nil
+	}
+
+	return addFractionalDays(excel1900LateEpoch, serial), nil
+}
+
+// Converts serial in Excel 1904 date system to time in UTC
+func FromExcelSerial1904(serial float64) (time.Time, error) {
+	if math.IsNaN(serial) || serial < 0 || serial >= excel1904MaxSerial+1 {
+		return time.Time{}, fmt.Errorf("excel 1904 serial %v out of range", serial)
+	}
+
+	return addFractionalDays(excel1904Epoch, serial), nil
+}
+
+// Converts OLE Automation date to time in UTC
+func FromOLEAutomationDate(serial float64) (time.Time, error) {
+	if math.IsNaN(serial) || serial <= oleMinSerial-1 || serial >= oleMaxSerial+1 {
+		return time.Time{}, fmt.Errorf("OLE automation date %v out of range", serial)
+	}
+
+	if serial >= 0 {
+		return addFractionalDays(oleEpoch, serial), nil
+	}
+
+	days, fraction := math.Modf(serial)
+	midnight := oleEpoch.AddDate(0, 0, int(days))
+
+	return addFractionalDays(midnight, -fraction), nil
+}
+
+// Parses serial date in a system matching given timezone type
+func ParseSerial(s string, tzType TimezoneType) (time.Time, error) {
+	serial, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch tzType {
+	case ExcelSerial1900TimezoneType:
+		return FromExcelSerial1900(serial)
+	case ExcelSerial1904TimezoneType:
+		return FromExcelSerial1904(serial)
+	case OLEAutomationTimezoneType:
+		return FromOLEAutomationDate(serial)
+	}
+
+	return time.Time{}, fmt.Errorf("timezone type %d is not a serial date", tzType)
+}
+
+// Tells if given timezone type holds spreadsheet serial dates
+func IsSerial(tzType TimezoneType) bool {
+	return tzType == ExcelSerial1900TimezoneType ||
+		tzType == ExcelSerial1904TimezoneType ||
+		tzType == OLEAutomationTimezoneType
+}
+
+func formatSerial(serial float64) string {
+	return strconv.FormatFloat(serial, 'f', -1, 64)
+}
+
+// time.Sub saturates after ~292 years and milliseconds overflow at the
+// edges of supported range, so whole days and the rest are counted apart,
+// which also avoids rounding noise like 45234.562500000004
+func fractionalDays(t time.Time, epoch time.Time) float64 {
+	secondsPerDay := int64(day / time.Second)
+	msPerDay := int64(day / time.Millisecond)
+
+	tDays, tSeconds := floorDiv(t.Unix(), secondsPerDay)
+	epochDays, epochSeconds := floorDiv(epoch.Unix(), secondsPerDay)
+
+	days := tDays - epochDays
+	ms := (tSeconds-epochSeconds)*1000 + int64(t.Nanosecond()-epoch.Nanosecond())/int64(time.Millisecond)
+
+	// rest of the day has the same sign as whole days
+	if days > 0 && ms < 0 {
+		days--
+		ms += msPerDay
+	} else if days < 0 && ms > 0 {
+		days++
+		ms -= msPerDay
+	}
+
+	return float64(days) + float64(ms)/float64(msPerDay)
+}
+
+// Division rounding towards negative infinity, remainder is never negative
+func floorDiv(a int64, b int64) (int64, int64) {
+	quotient, remainder := a/b, a%b
+	if remainder < 0 {
+		quotient--
+		remainder += b
+	}
+
+	return quotient, remainder
+}
+
+// Adds fractional number of days to given time, rounded to milliseconds
+// because spreadsheets do not keep more precision than that
+func addFractionalDays(t time.Time, days float64) time.Time {
+	whole, fraction := math.Modf(days)
+	ms := math.Round(fraction * float64(day/time.Millisecond))
+
+	return t.AddDate(0, 0, int(whole)).Add(time.Duration(ms) * time.Millisecond)
+}
@@ -0,0 +1,111 @@
+package timezone
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSerial(t *testing.T) {
+	tests := []struct {
+		name   string
+		tzType TimezoneType
+		serial string
+		time   time.Time
+	}{
+		{
+			name:   "Excel1900_Modern",
+			tzType: ExcelSerial1900TimezoneType,
+			serial: "45234.5625",
+			time:   time.Date(2023, time.November, 4, 13, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "Excel1900_FirstDay",
+			tzType: ExcelSerial1900TimezoneType,
+			serial: "1",
+			time:   time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "Excel1900_BeforeLeapYearBug",
+			tzType: ExcelSerial1900TimezoneType,
+			serial: "59",
+			time:   time.Date(1900, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "Excel1900_AfterLeapYearBug",
+			tzType: ExcelSerial1900TimezoneType,
+			serial: "61",
+			time:   time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "Excel1900_LastDay",
+			tzType: ExcelSerial1900TimezoneType,
+			serial: "2958465",
+			time:   time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "Excel1904_Modern",
+			tzType: ExcelSerial1904TimezoneType,
+			serial: "43772.5625",
+			time:   time.Date(2023, time.November, 4, 13, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "OLE_Epoch",
+			tzType: OLEAutomationTimezoneType,
+			serial: "0",
+			time:   time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "OLE_Modern",
+			tzType: OLEAutomationTimezoneType,
+			serial: "45234.5625",
+			time:   time.Date(2023, time.November, 4, 13, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "OLE_Negative",
+			tzType: OLEAutomationTimezoneType,
+			serial: "-1.25",
+			time:   time.Date(1899, time.December, 29, 6, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSerial(tt.serial, tt.tzType)
+			if err != nil {
+				t.Fatalf("ParseSerial() error = %v", err)
+			}
+
+			if !got.Equal(tt.time) {
+				t.Errorf("ParseSerial() = %v, want %v", got, tt.time)
+			}
+
+			td := TimezoneDefinition{Type: tt.tzType}
+			if formatted := td.StringTime(tt.time, time.RFC3339); formatted != tt.serial {
+				t.Errorf("StringTime() = %v, want %v", formatted, tt.serial)
+			}
+		})
+	}
+}
+
+func TestSerial_OutOfRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		tzType TimezoneType
+		serial string
+	}{
+		{"Excel1900_Negative", ExcelSerial1900TimezoneType, "-1"},
+		{"Excel1900_AfterYear9999", ExcelSerial1900TimezoneType, "2958466"},
+		{"Excel1904_Negative", ExcelSerial1904TimezoneType, "-0.5"},
+		{"OLE_BeforeYear100", OLEAutomationTimezoneType, "-657436"},
+		{"NotANumber", ExcelSerial1900TimezoneType, "abc"},
+		{"NotSerialType", UnixTimezoneType, "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSerial(tt.serial, tt.tzType); err == nil {
+				t.Errorf("ParseSerial(%q) expected error", tt.serial)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package timezone
 
 import (
+	"errors"
+	"log/slog"
 	"strconv"
 	"time"
 )
@@ -12,6 +14,13 @@ const (
 	WithLocationTimzoneType
 	UnixTimezoneType
 	FixedOffsetTimezoneType
+	ExcelSerial1900TimezoneType
+	ExcelSerial1904TimezoneType
+	OLEAutomationTimezoneType
+	JulianDateTimezoneType
+	ModifiedJulianDateTimezoneType
+	TAITimezoneType
+	TTTimezoneType
 )
 
 type TimezoneDefinition struct {
@@ -25,6 +34,20 @@ type TimezoneDefinition struct {
 func (td TimezoneDefinition) StringTime(t time.Time, format string) string {
 	if td.Type == UnixTimezoneType {
 		return strconv.FormatInt(t.Unix(), 10)
+	} else if td.Type == ExcelSerial1900TimezoneType {
+		return formatSerial(ToExcelSerial1900(t))
+	} else if td.Type == ExcelSerial1904TimezoneType {
+		return formatSerial(ToExcelSerial1904(t))
+	} else if td.Type == OLEAutomationTimezoneType {
+		return formatSerial(ToOLEAutomationDate(t))
+	} else if td.Type == JulianDateTimezoneType {
+		return formatJulian(ToJulianDate(t))
+	} else if td.Type == ModifiedJulianDateTimezoneType {
+		return formatJulian(ToModifiedJulianDate(t))
+	} else if td.Type == TAITimezoneType {
+		return ToTAI(t).Format(ScaleLayout(format, td.Type))
+	} else if td.Type == TTTimezoneType {
+		return ToTT(t).Format(ScaleLayout(format, td.Type))
 	} else if td.Type == FixedOffsetTimezoneType {
 		return t.In(time.FixedZone(td.Label, td.Offset)).Format(format)
 	} else {
@@ -32,10 +55,12 @@ func (td TimezoneDefinition) StringTime(t time.Time, format string) string {
 	}
 }
 
+// Location of the timezone, UTC when it is missing from tz database
 func (td TimezoneDefinition) Location() *time.Location {
 	loc, err := time.LoadLocation(td.LocationAsString)
 	if err != nil {
-		panic(err)
+		slog.Error("timezone cannot be loaded, using UTC", "timezone", td.LocationAsString, "err", err)
+		return time.UTC
 	}
 
 	return loc
@@ -65,6 +90,18 @@ const (
 	LastNamedId
 )
 
+// Ids of rows which are not wall clock in some zone,
+// they go after fixed offsets so saved ids keep their meaning
+const (
+	ExcelSerial1900 int = LastNamedId + 22 + iota
+	ExcelSerial1904
+	OLEAutomation
+	JulianDate
+	ModifiedJulianDate
+	TAI
+	TT
+)
+
 var Timezones = []TimezoneDefinition{
 	{
 		Id:               Local,
@@ -328,4 +365,63 @@ var Timezones = []TimezoneDefinition{
 		Offset:           11 * 60 * 60,
 		Type:             FixedOffsetTimezoneType,
 	},
+	{
+		Id:               ExcelSerial1900,
+		LocationAsString: "UTC",
+		Label:            "Excel serial (1900)",
+		Type:             ExcelSerial1900TimezoneType,
+	},
+	{
+		Id:               ExcelSerial1904,
+		LocationAsString: "UTC",
+		Label:            "Excel serial (1904)",
+		Type:             ExcelSerial1904TimezoneType,
+	},
+	{
+		Id:               OLEAutomation,
+		LocationAsString: "UTC",
+		Label:            "OLE Automation date",
+		Type:             OLEAutomationTimezoneType,
+	},
+	{
+		Id:               JulianDate,
+		LocationAsString: "UTC",
+		Label:            "Julian Date (UTC)",
+		Type:             JulianDateTimezoneType,
+	},
+	{
+		Id:               ModifiedJulianDate,
+		LocationAsString: "UTC",
+		Label:            "Modified Julian Date (UTC)",
+		Type:             ModifiedJulianDateTimezoneType,
+	},
+	{
+		Id:               TAI,
+		LocationAsString: "UTC",
+		Label:            "TAI (International Atomic Time)",
+		Type:             TAITimezoneType,
+	},
+	{
+		Id:               TT,
+		LocationAsString: "UTC",
+		Label:            "TT (Terrestrial Time)",
+		Type:             TTTimezoneType,
+	},
+}
+
+// Range of supported instants, which is what time.Time can hold
+// with Unix seconds in int64, kept a year inside so shifting to any
+// zone or time scale stays in range as well
+// Text formats read back only years 0 to 9999, other rows take the rest
+var (
+	MinTime = time.Date(-292277022398, time.January, 1, 0, 0, 0, 0, time.UTC)
+	MaxTime = time.Date(292277024626, time.December, 31, 23, 59, 59, 999999999, time.UTC)
+)
+
+// Returned when number does not map to an instant in supported range
+var ErrOutOfRange = errors.New("out of supported range")
+
+// Tells if given instant is in supported range
+func InRange(t time.Time) bool {
+	return !t.Before(MinTime) && !t.After(MaxTime)
 }
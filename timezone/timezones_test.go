@@ -0,0 +1,62 @@
+package timezone
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestInRange(t *testing.T) {
+	tests := []struct {
+		name string
+		time time.Time
+		want bool
+	}{
+		{"Min", MinTime, true},
+		{"BeforeMin", MinTime.Add(-time.Nanosecond), false},
+		{"Max", MaxTime, true},
+		{"AfterMax", MaxTime.Add(time.Nanosecond), false},
+		{"NegativeEpoch", time.Unix(-1000000000, 0), true},
+		{"Year1960", time.Date(1960, time.May, 1, 0, 0, 0, 0, time.UTC), true},
+		{"FarPast", time.Date(-1000000000, time.January, 1, 0, 0, 0, 0, time.UTC), true},
+		{"FarFuture", time.Date(1000000000, time.January, 1, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InRange(tt.time); got != tt.want {
+				t.Errorf("InRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringTime_NegativeEpoch(t *testing.T) {
+	unix := TimezoneDefinition{Type: UnixTimezoneType}
+	ts := time.Date(1960, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	if got := unix.StringTime(ts, time.RFC3339); got != "-315619200" {
+		t.Errorf("StringTime() = %v, want -315619200", got)
+	}
+}
+
+func TestStringTime_EdgesOfRange(t *testing.T) {
+	for _, ts := range []time.Time{MinTime, MaxTime} {
+		unix := TimezoneDefinition{Type: UnixTimezoneType}
+		if got, want := unix.StringTime(ts, time.RFC3339), strconv.FormatInt(ts.Unix(), 10); got != want {
+			t.Errorf("Unix StringTime(%v) = %v, want %v", ts, got, want)
+		}
+
+		// noon is a whole Julian Date, so it reads back exactly
+		noon := time.Date(ts.Year(), ts.Month(), ts.Day(), 12, 0, 0, 0, time.UTC)
+		jd := TimezoneDefinition{Type: JulianDateTimezoneType}
+		back, err := ParseJulian("JD " + jd.StringTime(noon, time.RFC3339))
+		if err != nil {
+			t.Fatalf("ParseJulian() error = %v", err)
+		}
+
+		if !back.Equal(noon) {
+			t.Errorf("ParseJulian() = %v, want %v", back, noon)
+		}
+	}
+}
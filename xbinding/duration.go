@@ -0,0 +1,14 @@
+package xbinding
+
+import "time"
+
+// Binding of a time.Duration, e.g. offset of a pinned timezone
+type Duration struct {
+	Value[time.Duration]
+}
+
+func NewDuration() Duration {
+	return Duration{
+		Value: NewValue[time.Duration](),
+	}
+}
@@ -0,0 +1,154 @@
+package xbinding
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// Binding of a list of values of any type, listeners are called
+// the same way as by fyne bindings, whenever content of the list changes
+type List[T any] struct {
+	value binding.UntypedList
+
+	// changes whenever content of the list changes, listeners are added to it,
+	// because fyne lists call them only when the length changes
+	revision binding.Int
+
+	// shared by copies of the list, so changes are not interleaved
+	lock *sync.Mutex
+}
+
+func NewList[T any]() List[T] {
+	return List[T]{
+		value:    binding.NewUntypedList(),
+		revision: binding.NewInt(),
+		lock:     &sync.Mutex{},
+	}
+}
+
+// Replaces content of the list, setting equal content does not call listeners
+func (l *List[T]) Set(value []T) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.set(value)
+}
+
+func (l *List[T]) set(value []T) error {
+	current, err := l.Get()
+	if err != nil {
+		return err
+	}
+
+	if len(current) == len(value) && (len(value) == 0 || reflect.DeepEqual(current, value)) {
+		return nil
+	}
+
+	interfaces := make([]interface{}, len(value))
+	for i, v := range value {
+		interfaces[i] = v
+	}
+
+	if err := l.value.Set(interfaces); err != nil {
+		return err
+	}
+
+	return l.changed()
+}
+
+func (l *List[T]) changed() error {
+	revision, err := l.revision.Get()
+	if err != nil {
+		return err
+	}
+
+	return l.revision.Set(revision + 1)
+}
+
+// Returns copy of the list, never nil
+func (l *List[T]) Get() ([]T, error) {
+	values, err := l.value.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]T, len(values))
+	for i, v := range values {
+		typed, ok := v.(T)
+		if !ok {
+			return nil, fmt.Errorf("item %d %v is %T, not %T", i, v, v, ret[i])
+		}
+
+		ret[i] = typed
+	}
+
+	return ret, nil
+}
+
+func (l *List[T]) Length() int {
+	return l.value.Length()
+}
+
+func (l *List[T]) Append(value T) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if err := l.value.Append(value); err != nil {
+		return err
+	}
+
+	return l.changed()
+}
+
+// Removes item at given index
+func (l *List[T]) Remove(index int) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	values, err := l.Get()
+	if err != nil {
+		return err
+	}
+
+	if index < 0 || index >= len(values) {
+		return fmt.Errorf("index %d out of range of list of %d items", index, len(values))
+	}
+
+	return l.set(append(values[:index], values[index+1:]...))
+}
+
+// Moves item from one index to another, items between them shift by one
+func (l *List[T]) Move(from int, to int) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	values, err := l.Get()
+	if err != nil {
+		return err
+	}
+
+	if from < 0 || from >= len(values) || to < 0 || to >= len(values) {
+		return fmt.Errorf("cannot move item %d to %d in list of %d items", from, to, len(values))
+	}
+
+	if from == to {
+		return nil
+	}
+
+	item := values[from]
+	values = append(values[:from], values[from+1:]...)
+	values = append(values[:to], append([]T{item}, values[to:]...)...)
+
+	return l.set(values)
+}
+
+func (l *List[T]) AddListener(listener binding.DataListener) {
+	l.revision.AddListener(listener)
+}
+
+func (l *List[T]) RemoveListener(listener binding.DataListener) {
+	l.revision.RemoveListener(listener)
+}
@@ -0,0 +1,24 @@
+package xbinding
+
+import "time"
+
+// Binding of a *time.Location, locations are equal if they have the same name,
+// so loading the same zone again does not call listeners
+type Location struct {
+	Value[*time.Location]
+}
+
+func NewLocation() Location {
+	return Location{
+		Value: NewValueWithEqual(LocationsEqual),
+	}
+}
+
+// Reports whether both locations have the same name, nil is equal only to nil
+func LocationsEqual(a, b *time.Location) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.String() == b.String()
+}
@@ -0,0 +1,150 @@
+package xbinding
+
+import (
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// Converts time to text and back, e.g. in given location,
+// or as Unix timestamp which does not need any layout
+type TimeZone interface {
+	Format(t time.Time, layout string) string
+	Parse(text string, layout string) (time.Time, error)
+}
+
+type locationZone struct {
+	location *time.Location
+}
+
+func (z locationZone) Format(t time.Time, layout string) string {
+	return t.In(z.location).Format(layout)
+}
+
+func (z locationZone) Parse(text string, layout string) (time.Time, error) {
+	return time.ParseInLocation(layout, text, z.location)
+}
+
+// Returns zone which formats and parses time in given location
+func InLocation(location *time.Location) TimeZone {
+	return locationZone{location: location}
+}
+
+// String binding of time formatted in a zone, it is formatted on read
+// and parsed on write, so it can be bound directly to widget.Entry
+// Text which cannot be parsed leaves the time as it is, the error
+// is returned by Set, so entry shows it, and kept until next Set
+type TimeString struct {
+	source *Time
+	format binding.String
+	zone   TimeZone
+
+	// changes whenever source or format changes, listeners are added to it,
+	// so they are called the same way as listeners of fyne bindings
+	revision binding.Int
+	changed  binding.DataListener
+
+	lock sync.RWMutex
+	err  error
+
+	// optional, called with the time parsed from text once it is set,
+	// before listeners, which are called asynchronously
+	OnSet func(t time.Time)
+}
+
+var _ binding.String = (*TimeString)(nil)
+
+func NewTimeString(source *Time, format binding.String, zone TimeZone) *TimeString {
+	s := &TimeString{
+		source:   source,
+		format:   format,
+		zone:     zone,
+		revision: binding.NewInt(),
+	}
+
+	s.changed = binding.NewDataListener(func() {
+		revision, _ := s.revision.Get()
+		s.revision.Set(revision + 1)
+	})
+
+	source.AddListener(s.changed)
+	format.AddListener(s.changed)
+
+	return s
+}
+
+// Removes listeners added to the source and format, so the binding
+// can be dropped while they are still in use, its listeners are not called anymore
+func (s *TimeString) Close() {
+	s.source.RemoveListener(s.changed)
+	s.format.RemoveListener(s.changed)
+}
+
+func (s *TimeString) Get() (string, error) {
+	t, err := s.source.Get()
+	if err != nil {
+		return "", err
+	}
+
+	layout, err := s.format.Get()
+	if err != nil {
+		return "", err
+	}
+
+	return s.zone.Format(t, layout), nil
+}
+
+// Parses text and sets the time, unless it is the same already,
+// so text typed by user is not replaced with the formatted one
+func (s *TimeString) Set(text string) error {
+	layout, err := s.format.Get()
+	if err != nil {
+		return err
+	}
+
+	t, err := s.zone.Parse(text, layout)
+
+	s.lock.Lock()
+	s.err = err
+	s.lock.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	current, err := s.source.Get()
+	if err != nil {
+		return err
+	}
+
+	if current.Equal(t) {
+		return nil
+	}
+
+	if err := s.source.Set(t); err != nil {
+		return err
+	}
+
+	if s.OnSet != nil {
+		s.OnSet(t)
+	}
+
+	return nil
+}
+
+// Returns error of the last Set, nil if text was parsed
+func (s *TimeString) Err() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.err
+}
+
+func (s *TimeString) AddListener(listener binding.DataListener) {
+	s.revision.AddListener(listener)
+}
+
+func (s *TimeString) RemoveListener(listener binding.DataListener) {
+	s.revision.RemoveListener(listener)
+}
@@ -0,0 +1,80 @@
+package xbinding
+
+import (
+	"fmt"
+	"reflect"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// Binding of a single value of any type, listeners are called
+// the same way as by fyne bindings
+// Value is kept behind a pointer, binding.Untyped compares values with ==,
+// which panics for slices and maps
+type Value[T any] struct {
+	value binding.Untyped
+	// setting value equal to the current one does not call listeners,
+	// reflect.DeepEqual is used when it is nil
+	equal func(a, b T) bool
+}
+
+func NewValue[T any]() Value[T] {
+	return Value[T]{
+		value: binding.NewUntyped(),
+	}
+}
+
+// Creates binding which compares values with given function,
+// for types where == is not enough, e.g. pointers to equal values
+func NewValueWithEqual[T any](equal func(a, b T) bool) Value[T] {
+	return Value[T]{
+		value: binding.NewUntyped(),
+		equal: equal,
+	}
+}
+
+func (v *Value[T]) Set(value T) error {
+	equal := v.equal
+	if equal == nil {
+		equal = func(a, b T) bool { return reflect.DeepEqual(a, b) }
+	}
+
+	// first value is always set, listeners are told even about zero value
+	if stored, err := v.value.Get(); err == nil && stored != nil {
+		current, err := v.Get()
+		if err == nil && equal(current, value) {
+			return nil
+		}
+	}
+
+	return v.value.Set(&value)
+}
+
+// Returns zero value if nothing was set yet
+func (v *Value[T]) Get() (T, error) {
+	var zero T
+
+	value, err := v.value.Get()
+	if err != nil {
+		return zero, err
+	}
+
+	if value == nil {
+		return zero, nil
+	}
+
+	typed, ok := value.(*T)
+	if !ok {
+		return zero, fmt.Errorf("value %v is %T, not %T", value, value, zero)
+	}
+
+	return *typed, nil
+}
+
+func (v *Value[T]) AddListener(listener binding.DataListener) {
+	v.value.AddListener(listener)
+}
+
+func (v *Value[T]) RemoveListener(listener binding.DataListener) {
+	v.value.RemoveListener(listener)
+}
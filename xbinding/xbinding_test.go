@@ -2,8 +2,11 @@ package xbinding
 
 import (
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"fyne.io/fyne/v2/data/binding"
 )
 
 func TestTime(t *testing.T) {
@@ -81,3 +84,487 @@ func TestIntArray(t *testing.T) {
 		})
 	}
 }
+
+func TestValue_ZeroBeforeSet(t *testing.T) {
+	v := NewValue[time.Duration]()
+
+	got, err := v.Get()
+	if err != nil {
+		t.Errorf("Value.Get() error = %v", err)
+	}
+
+	if got != 0 {
+		t.Errorf("Value.Get() = %v, want zero", got)
+	}
+}
+
+func TestValue_Types(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func() (interface{}, interface{}, error)
+	}{
+		{
+			name: "string",
+			check: func() (interface{}, interface{}, error) {
+				v := NewValue[string]()
+				v.Set("value")
+				got, err := v.Get()
+				return got, "value", err
+			},
+		},
+		{
+			name: "struct",
+			check: func() (interface{}, interface{}, error) {
+				type point struct{ X, Y int }
+				v := NewValue[point]()
+				v.Set(point{1, 2})
+				got, err := v.Get()
+				return got, point{1, 2}, err
+			},
+		},
+		{
+			name: "location",
+			check: func() (interface{}, interface{}, error) {
+				v := NewValue[*time.Location]()
+				v.Set(time.UTC)
+				got, err := v.Get()
+				return got, time.UTC, err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, want, err := tt.check()
+			if err != nil {
+				t.Errorf("Value.Get() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Value.Get() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestValue_SetSliceTwice(t *testing.T) {
+	v := NewValue[[]string]()
+	called := make(chan struct{}, 10)
+	v.AddListener(binding.NewDataListener(func() { called <- struct{}{} }))
+	<-called
+
+	for _, value := range [][]string{{"a"}, {"a", "b"}} {
+		if err := v.Set(value); err != nil {
+			t.Fatalf("Value.Set(%v) error = %v", value, err)
+		}
+
+		select {
+		case <-called:
+		case <-time.After(time.Second):
+			t.Fatalf("listener was not called after Set(%v)", value)
+		}
+	}
+
+	got, err := v.Get()
+	if err != nil || !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Value.Get() = %v, %v, want [a b]", got, err)
+	}
+
+	// equal slice is not a change
+	v.Set([]string{"a", "b"})
+
+	select {
+	case <-called:
+		t.Errorf("listener was called after setting equal value")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestList_Items(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial []string
+		change  func(l *List[string]) error
+		want    []string
+		wantErr bool
+		// number of listener calls caused by the change
+		wantCalls int
+	}{
+		{
+			name:      "Append",
+			initial:   []string{"a"},
+			change:    func(l *List[string]) error { return l.Append("b") },
+			want:      []string{"a", "b"},
+			wantCalls: 1,
+		},
+		{
+			name:      "Append_Empty",
+			initial:   []string{},
+			change:    func(l *List[string]) error { return l.Append("a") },
+			want:      []string{"a"},
+			wantCalls: 1,
+		},
+		{
+			name:      "Remove_Middle",
+			initial:   []string{"a", "b", "c"},
+			change:    func(l *List[string]) error { return l.Remove(1) },
+			want:      []string{"a", "c"},
+			wantCalls: 1,
+		},
+		{
+			name:      "Remove_OutOfRange",
+			initial:   []string{"a"},
+			change:    func(l *List[string]) error { return l.Remove(1) },
+			want:      []string{"a"},
+			wantErr:   true,
+			wantCalls: 0,
+		},
+		{
+			name:      "Move_Down",
+			initial:   []string{"a", "b", "c", "d"},
+			change:    func(l *List[string]) error { return l.Move(0, 2) },
+			want:      []string{"b", "c", "a", "d"},
+			wantCalls: 1,
+		},
+		{
+			name:      "Move_Up",
+			initial:   []string{"a", "b", "c", "d"},
+			change:    func(l *List[string]) error { return l.Move(3, 1) },
+			want:      []string{"a", "d", "b", "c"},
+			wantCalls: 1,
+		},
+		{
+			name:      "Move_Same",
+			initial:   []string{"a", "b"},
+			change:    func(l *List[string]) error { return l.Move(1, 1) },
+			want:      []string{"a", "b"},
+			wantCalls: 0,
+		},
+		{
+			name:      "Move_OutOfRange",
+			initial:   []string{"a", "b"},
+			change:    func(l *List[string]) error { return l.Move(0, 2) },
+			want:      []string{"a", "b"},
+			wantErr:   true,
+			wantCalls: 0,
+		},
+		{
+			name:      "Set_SameLength",
+			initial:   []string{"a", "b"},
+			change:    func(l *List[string]) error { return l.Set([]string{"b", "a"}) },
+			want:      []string{"b", "a"},
+			wantCalls: 1,
+		},
+		{
+			name:      "Set_Equal",
+			initial:   []string{"a", "b"},
+			change:    func(l *List[string]) error { return l.Set([]string{"a", "b"}) },
+			want:      []string{"a", "b"},
+			wantCalls: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewList[string]()
+			l.Set(tt.initial)
+
+			calls := make(chan struct{}, 10)
+			l.AddListener(binding.NewDataListener(func() { calls <- struct{}{} }))
+			// fyne bindings call listener right after it is added
+			<-calls
+
+			if err := tt.change(&l); (err != nil) != tt.wantErr {
+				t.Errorf("change error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got, err := l.Get()
+			if err != nil {
+				t.Errorf("List.Get() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List.Get() = %v, want %v", got, tt.want)
+			}
+
+			if l.Length() != len(tt.want) {
+				t.Errorf("List.Length() = %d, want %d", l.Length(), len(tt.want))
+			}
+
+			if got := countCalls(calls, tt.wantCalls); got != tt.wantCalls {
+				t.Errorf("listener called %d times, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// Waits for want calls, then a bit longer for unexpected ones
+func countCalls(calls <-chan struct{}, want int) int {
+	got := 0
+	for got < want {
+		select {
+		case <-calls:
+			got++
+		case <-time.After(time.Second):
+			return got
+		}
+	}
+
+	for {
+		select {
+		case <-calls:
+			got++
+		case <-time.After(50 * time.Millisecond):
+			return got
+		}
+	}
+}
+
+func TestList_GetReturnsCopy(t *testing.T) {
+	l := NewList[int]()
+	l.Set([]int{1, 2})
+
+	got, _ := l.Get()
+	got[0] = 5
+
+	again, _ := l.Get()
+	if again[0] != 1 {
+		t.Errorf("changing returned slice changed the list: %v", again)
+	}
+}
+
+func TestListeners(t *testing.T) {
+	v := NewValue[int]()
+	called := make(chan struct{}, 10)
+	listener := binding.NewDataListener(func() { called <- struct{}{} })
+
+	waitCalled := func() bool {
+		select {
+		case <-called:
+			return true
+		case <-time.After(time.Second):
+			return false
+		}
+	}
+
+	// fyne bindings call listener right after it is added
+	v.AddListener(listener)
+	if !waitCalled() {
+		t.Fatalf("listener was not called after AddListener")
+	}
+
+	v.Set(1)
+	if !waitCalled() {
+		t.Fatalf("listener was not called after Set")
+	}
+
+	v.RemoveListener(listener)
+	v.Set(2)
+
+	select {
+	case <-called:
+		t.Errorf("listener was called after RemoveListener")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestTimeString(t *testing.T) {
+	source := NewTime()
+	source.Set(time.Date(2023, 3, 14, 15, 9, 26, 0, time.UTC))
+	format := binding.NewString()
+	format.Set(time.RFC3339)
+
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	s := NewTimeString(&source, format, InLocation(warsaw))
+
+	tests := []struct {
+		name     string
+		text     string
+		wantErr  bool
+		wantTime time.Time
+		wantText string
+	}{
+		{
+			name:     "Valid",
+			text:     "2024-01-02T03:04:05+01:00",
+			wantTime: time.Date(2024, 1, 2, 2, 4, 5, 0, time.UTC),
+			wantText: "2024-01-02T03:04:05+01:00",
+		},
+		{
+			name:     "Invalid_KeepsTime",
+			text:     "2024-01-02T03:04",
+			wantErr:  true,
+			wantTime: time.Date(2024, 1, 2, 2, 4, 5, 0, time.UTC),
+			wantText: "2024-01-02T03:04:05+01:00",
+		},
+		{
+			name:     "OtherOffset_Converted",
+			text:     "2024-01-02T00:00:00Z",
+			wantTime: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			wantText: "2024-01-02T01:00:00+01:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Set(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TimeString.Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if s.Err() != err {
+				t.Errorf("TimeString.Err() = %v, want %v", s.Err(), err)
+			}
+
+			got, _ := source.Get()
+			if !got.Equal(tt.wantTime) {
+				t.Errorf("source = %v, want %v", got, tt.wantTime)
+			}
+
+			text, _ := s.Get()
+			if text != tt.wantText {
+				t.Errorf("TimeString.Get() = %q, want %q", text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestTimeString_Listeners(t *testing.T) {
+	source := NewTime()
+	source.Set(time.Date(2023, 3, 14, 15, 9, 26, 0, time.UTC))
+	format := binding.NewString()
+	format.Set(time.RFC3339)
+
+	s := NewTimeString(&source, format, InLocation(time.UTC))
+
+	texts := make(chan string, 10)
+	s.AddListener(binding.NewDataListener(func() {
+		text, _ := s.Get()
+		texts <- text
+	}))
+
+	waitText := func(want string) {
+		deadline := time.After(time.Second)
+		for {
+			select {
+			case text := <-texts:
+				if text == want {
+					return
+				}
+			case <-deadline:
+				t.Fatalf("listener was not called with %q", want)
+			}
+		}
+	}
+
+	waitText("2023-03-14T15:09:26Z")
+
+	format.Set(time.Kitchen)
+	waitText("3:09PM")
+
+	source.Set(time.Date(2023, 3, 14, 8, 0, 0, 0, time.UTC))
+	waitText("8:00AM")
+}
+
+func TestTimeString_Close(t *testing.T) {
+	source := NewTime()
+	source.Set(time.Date(2023, 3, 14, 15, 9, 26, 0, time.UTC))
+	format := binding.NewString()
+	format.Set(time.RFC3339)
+
+	s := NewTimeString(&source, format, InLocation(time.UTC))
+
+	var calls atomic.Int32
+	s.AddListener(binding.NewDataListener(func() { calls.Add(1) }))
+
+	// listeners are called in order, so the one added last comes after the queued ones,
+	// which may queue another round
+	wait := func() {
+		for i := 0; i < 2; i++ {
+			done := make(chan struct{})
+			binding.NewBool().AddListener(binding.NewDataListener(func() { close(done) }))
+			<-done
+		}
+	}
+
+	wait()
+	if calls.Load() == 0 {
+		t.Fatal("listener was not called")
+	}
+
+	s.Close()
+	calls.Store(0)
+	source.Set(time.Date(2023, 3, 14, 8, 0, 0, 0, time.UTC))
+	format.Set(time.Kitchen)
+	wait()
+
+	if calls.Load() != 0 {
+		t.Error("listener should not be called after Close")
+	}
+}
+
+func TestLocation_Equality(t *testing.T) {
+	first, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	// another pointer to the same zone
+	second, _ := time.LoadLocation("Europe/Warsaw")
+
+	tests := []struct {
+		name string
+		a, b *time.Location
+		want bool
+	}{
+		{"SameName", first, second, true},
+		{"DifferentName", first, time.UTC, false},
+		{"BothNil", nil, nil, true},
+		{"OneNil", first, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LocationsEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("LocationsEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	l := NewLocation()
+	l.Set(first)
+
+	calls := make(chan struct{}, 10)
+	l.AddListener(binding.NewDataListener(func() { calls <- struct{}{} }))
+	<-calls
+
+	l.Set(second)
+
+	select {
+	case <-calls:
+		t.Errorf("listener was called for location with the same name")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	l.Set(time.UTC)
+
+	select {
+	case <-calls:
+	case <-time.After(time.Second):
+		t.Errorf("listener was not called for another location")
+	}
+}
+
+func TestDuration(t *testing.T) {
+	d := NewDuration()
+
+	if got, _ := d.Get(); got != 0 {
+		t.Errorf("Duration.Get() = %v before Set, want 0", got)
+	}
+
+	d.Set(90 * time.Minute)
+
+	if got, _ := d.Get(); got != 90*time.Minute {
+		t.Errorf("Duration.Get() = %v, want %v", got, 90*time.Minute)
+	}
+}